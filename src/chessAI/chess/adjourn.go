@@ -0,0 +1,63 @@
+package chess
+
+import "encoding/json"
+import "fmt"
+import "os"
+import "time"
+
+// adjournedGame is what's saved when a game is adjourned, to resume it later. The FEN includes whose move it is.
+type adjournedGame struct {
+	Players int `json:"players"`
+	FEN string `json:"fen"`
+	Plies int `json:"plies"`
+	Clock *adjournedClock `json:"clock,omitempty"` // missing for games without a clock
+}
+
+type adjournedClock struct {
+	WhiteMs int64 `json:"white_ms"`
+	BlackMs int64 `json:"black_ms"`
+	IncrementMs int64 `json:"increment_ms"`
+	// the times the game started with, for the time control; missing in older files, which start from the times left
+	InitialWhiteMs int64 `json:"initial_white_ms,omitempty"`
+	InitialBlackMs int64 `json:"initial_black_ms,omitempty"`
+}
+
+// AdjournGame saves a game adjourned with the given result, so ResumeGame can continue it
+func AdjournGame(fileName string, players int, result Result, clock *Clock) error {
+	game := adjournedGame{ players, ToFEN(result.finalPosition), result.plies, nil }
+	if clock != nil {
+		game.Clock = &adjournedClock{
+			clock.Remaining(PieceColor_White).Milliseconds(), clock.Remaining(PieceColor_Black).Milliseconds(),
+			clock.increment.Milliseconds(), clock.Initial(PieceColor_White).Milliseconds(),
+			clock.Initial(PieceColor_Black).Milliseconds(),
+		}
+	}
+
+	data, err := json.MarshalIndent(game, "", "  ")
+	if err != nil { return err }
+	return os.WriteFile(fileName, data, 0644)
+}
+
+// ResumeGame loads a game saved by AdjournGame
+func ResumeGame(fileName string) (players int, position Position, plies int, clock *Clock, err error) {
+	data, err := os.ReadFile(fileName)
+	if err != nil { return }
+
+	var game adjournedGame
+	if err = json.Unmarshal(data, &game); err != nil { return }
+	if game.Players < 0 || game.Players > 2 {
+		err = fmt.Errorf("invalid number of players %d", game.Players)
+		return
+	}
+	if position, err = ParseFEN(game.FEN); err != nil { return }
+
+	if game.Clock != nil {
+		clock = NewOddsClock(time.Duration(game.Clock.WhiteMs) * time.Millisecond,
+			time.Duration(game.Clock.BlackMs) * time.Millisecond, time.Duration(game.Clock.IncrementMs) * time.Millisecond)
+		if game.Clock.InitialWhiteMs > 0 && game.Clock.InitialBlackMs > 0 {
+			clock.initial[PieceColor_White] = time.Duration(game.Clock.InitialWhiteMs) * time.Millisecond
+			clock.initial[PieceColor_Black] = time.Duration(game.Clock.InitialBlackMs) * time.Millisecond
+		}
+	}
+	return game.Players, position, game.Plies, clock, nil
+}
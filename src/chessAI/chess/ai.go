@@ -0,0 +1,805 @@
+//go:build !rulesonly
+
+package chess
+
+import "fmt"
+import "math"
+import "sort"
+import "strings"
+import "time"
+
+type BoardScore struct {
+	board Board
+	score int
+}
+
+// Board returns the move, as the board after it
+func (b BoardScore) Board() Board {
+	return b.board
+}
+
+// Score returns the score of the move, from the point of view of the side that plays it
+func (b BoardScore) Score() int {
+	return b.score
+}
+
+// SearchOptions configures how the computer chooses its moves
+type SearchOptions struct {
+	depth int // maximum search depth
+	swindle bool // when losing, prefer moves the opponent is more likely to answer badly
+	maxMemoryMB int // memory budget for the transposition table
+	moveTime time.Duration // time available for the move, 0 means no limit
+	moveOverhead time.Duration // part of moveTime kept aside for everything but the search itself
+	opponentTime time.Duration // time left on the opponent's clock, 0 if unknown
+	observer SearchObserver // if set, told by SearchBestMove about its progress
+	startDepth int // first depth searched by SearchBestMove, to resume an earlier search; 0 means 1
+	easyMove bool // with moveTime, play right away when there's only one move or an obvious recapture
+	previousBoard Board // the board before the opponent's last move, zero if unknown
+	history map[positionKey]int // times each position was reached in the game so far, nil if unknown
+	evaluate func(Position) int // static evaluation of the positions at the end of the search, nil means EvaluateBoard
+	quiescenceDepth int // safety limit on the captures searched after depth until the position is quiet, 0 to evaluate right away
+	table *TranspositionTable // kept from one search to the next by whoever owns it, nil for a new one for each search
+}
+
+// lowMemoryMB is the memory budget of the low memory profile, for browsers and small devices. The transposition table
+// is the only big buffer the search has.
+const lowMemoryMB = 4
+
+func DefaultSearchOptions() SearchOptions {
+	return SearchOptions{ depth: 3, maxMemoryMB: defaultMemoryMB, moveOverhead: 50 * time.Millisecond, easyMove: true,
+		quiescenceDepth: 32 }
+}
+
+// Depth returns the maximum search depth, in plies
+func (o SearchOptions) Depth() int {
+	return o.depth
+}
+
+// Swindle tells whether, when losing, the search prefers moves the opponent is more likely to answer badly
+func (o SearchOptions) Swindle() bool {
+	return o.swindle
+}
+
+// MemoryMB returns the memory budget of the transposition table
+func (o SearchOptions) MemoryMB() int {
+	return o.maxMemoryMB
+}
+
+// MoveTime returns the time available for each move, 0 if there's no limit
+func (o SearchOptions) MoveTime() time.Duration {
+	return o.moveTime
+}
+
+// MoveOverhead returns the part of the move time kept aside for everything but the search
+func (o SearchOptions) MoveOverhead() time.Duration {
+	return o.moveOverhead
+}
+
+// EasyMove tells whether, with a move time, obvious moves are played without using the time available
+func (o SearchOptions) EasyMove() bool {
+	return o.easyMove
+}
+
+// LowMemoryMB returns the memory budget of the low memory profile
+func LowMemoryMB() int {
+	return lowMemoryMB
+}
+
+func getPiecesScore(board Board, color PieceColor) int {
+	score := 0
+	ForEachPiece(board, func(pos Square, info PieceInfo) {
+		if info.color == color { score += pieceScoreMap[info.piece] }
+	})
+	
+	return score
+}
+
+// king safety: a bonus for keeping the right to castle (or having castled already), and one for every
+// shelterPawnsPerPoint pawns sheltering the king, or the square it would castle to. All of it is worth about a pawn,
+// so it never pays to give material away to take it from the enemy.
+var castlingRightsScore = 1
+var shelterPawnsPerPoint = 2
+
+// shelterPawns counts the pawns of color right in front of a king on the back rank at file kingFile, on its file and
+// the ones next to it
+func shelterPawns(board Board, color PieceColor, kingFile int) int {
+	row, forward := 0, 1
+	if color == PieceColor_White { row, forward = 7, -1 }
+
+	count := 0
+	for x := kingFile - 1; x <= kingFile + 1; x ++ {
+		for y := row + forward; y != row + 3 * forward; y += forward {
+			pos := Square{ x, y }
+			if !SquareInBoard(pos) { continue }
+			info := GetBoardAt(board, pos)
+			if info.piece == Piece_Pawn && info.color == color {
+				count ++
+				break
+			}
+		}
+	}
+	return count
+}
+
+// getKingSafetyScore rewards color for keeping its castling rights and the pawns in front of its king (or in front of
+// where the king would castle to), so castling rights aren't thrown away for small gains. It only counts while
+// the enemy has a queen, as in endgames the king has to come out anyway.
+func getKingSafetyScore(board Board, color PieceColor) int {
+	if len(GetPieces(board, Piece_Queen, !color)) == 0 { return 0 }
+
+	kingPos := GetPieces(board, Piece_King, color)[0]
+	row := 0
+	if color == PieceColor_White { row = 7 }
+	if kingPos.y != row { return 0 }
+
+	kingSide, queenSide := canStillCastle(board, color, 1), canStillCastle(board, color, -1)
+	if !kingSide && !queenSide {
+		// only a king that castled, or walked to a corner, is sheltered; it still gets the castling bonus, or
+		// castling would cost it
+		if kingPos.x > 2 && kingPos.x < 6 { return 0 }
+		return castlingRightsScore + shelterPawns(board, color, kingPos.x) / shelterPawnsPerPoint
+	}
+
+	shelter := 0
+	if kingSide { shelter = shelterPawns(board, color, 6) }
+	if queenSide { shelter = int(math.Max(float64(shelter), float64(shelterPawns(board, color, 2)))) }
+	return castlingRightsScore + shelter / shelterPawnsPerPoint
+}
+
+// king attack: a point for every kingAttackPerPoint files next to the enemy king without pawns of the attacker (so its
+// rocks and queen can use them) or with an attacker pawn storming up to the king
+var kingAttackPerPoint = 2
+
+// getKingAttackScore rewards color for half-open files and pawn storms aimed at the enemy king. Pawns only storm
+// when they don't shelter color's own king, which is when the kings are on opposite wings. Like king safety, it
+// only counts while color has a queen.
+func getKingAttackScore(board Board, color PieceColor) int {
+	if len(GetPieces(board, Piece_Queen, color)) == 0 { return 0 }
+
+	enemyKing := GetPieces(board, Piece_King, !color)[0]
+	ownKing := GetPieces(board, Piece_King, color)[0]
+	oppositeWings := math.Abs(float64(enemyKing.x - ownKing.x)) >= 3
+
+	// the enemy half of the board, where pawns are storming
+	enemyHalf := func(y int) bool { return y >= 4 }
+	if color == PieceColor_White { enemyHalf = func(y int) bool { return y <= 3 } }
+
+	points := 0
+	for x := enemyKing.x - 1; x <= enemyKing.x + 1; x ++ {
+		if x < 0 || x > 7 { continue }
+		halfOpen, storm := true, false
+		for y := 0; y < 8; y ++ {
+			info := GetBoardAt(board, Square{ x, y })
+			if info.piece != Piece_Pawn || info.color != color { continue }
+			halfOpen = false
+			if oppositeWings && enemyHalf(y) { storm = true }
+		}
+		if halfOpen || storm { points ++ }
+	}
+	return points / kingAttackPerPoint
+}
+
+var drawScore = 0
+var checkMateScore = 1000
+
+// terminalScore scores a position where the side to move has no moves available, from its point of view.
+// depthLeft rewards reaching a checkmate in fewer moves.
+func terminalScore(position Position, depthLeft int) int {
+	kingPos := GetPieces(position.board, Piece_King, position.sideToMove)[0]
+	if isUnderAttack(position.board, kingPos, position.sideToMove) { return - checkMateScore - depthLeft }
+	return drawScore
+}
+
+// EvaluateBoard gives a static score for a position, always from the point of view of the side to move
+func EvaluateBoard(position Position) int {
+	color := position.sideToMove
+	filterCheckMoves := true
+
+	moveCount := GetPossibleMoveCount(position.board, color, filterCheckMoves)
+	if moveCount == 0 { return terminalScore(position, 0) }
+
+	enemyMoveCount := GetPossibleMoveCount(position.board, !color, filterCheckMoves)
+	moveScore := moveCount - enemyMoveCount
+
+	pieceScore := getPiecesScore(position.board, color)
+	enemyPieceScore := getPiecesScore(position.board, !color)
+	combinedPieceScore := pieceScore - enemyPieceScore
+
+	kingSafetyScore := getKingSafetyScore(position.board, color) - getKingSafetyScore(position.board, !color)
+	kingAttackScore := getKingAttackScore(position.board, color) - getKingAttackScore(position.board, !color)
+
+	return moveScore + combinedPieceScore * 2 + kingSafetyScore + kingAttackScore
+}
+
+// MaterialEvaluation is a static score that only counts material, from the point of view of the side to move: it
+// plays greedily, grabbing whatever it can
+func MaterialEvaluation(position Position) int {
+	filterCheckMoves := true
+	if GetPossibleMoveCount(position.board, position.sideToMove, filterCheckMoves) == 0 { return terminalScore(position, 0) }
+	return getPiecesScore(position.board, position.sideToMove) - getPiecesScore(position.board, !position.sideToMove)
+}
+
+var biggestScore = 100000
+var lowestScore = - biggestScore
+
+// centipawnsPerUnit converts scores to the centipawns of UCI and EPD: EvaluateBoard counts material twice, so a
+// pawn is worth 2 units
+const centipawnsPerUnit = 50
+
+// ToCentipawns converts a score to centipawns, as UCI and EPD write them
+func ToCentipawns(score int) int {
+	return score * centipawnsPerUnit
+}
+
+// fromCentipawns converts centipawns to a score, to the nearest unit
+func fromCentipawns(centipawns int) int {
+	return int(math.Round(float64(centipawns) / centipawnsPerUnit))
+}
+
+// search holds the state shared by all the nodes of a search
+type search struct {
+	table *TranspositionTable // kept for the whole search, also to build the principal variation
+	deadline time.Time // zero if there's no time limit
+	aborted bool
+	nodes int // positions visited
+	stats NodeStats // of the current iteration
+	history map[positionKey]int // positions reached in the game, which score as draws if they're repeated
+	evaluate func(Position) int
+	quiescenceDepth int
+}
+
+// NodeStats classifies the nodes of a search by how their score compared to the alpha beta window, to measure how
+// good the move ordering is: with perfect ordering, the first move causes every cutoff.
+type NodeStats struct {
+	nodes int // positions visited, including leaves
+	pv int // nodes whose score fell inside the window
+	cut int // nodes where a move caused a beta cutoff
+	all int // nodes where no move raised alpha
+	firstMoveCuts int // cut nodes where the cutoff came from the first move
+	branchingFactor float64 // nodes of this depth for each node of the previous one, 0 for the first depth
+}
+
+// FirstMoveCutPercentage returns how many of the cutoffs came from the first move searched
+func (n NodeStats) FirstMoveCutPercentage() float64 {
+	if n.cut == 0 { return 0 }
+	return float64(n.firstMoveCuts) * 100 / float64(n.cut)
+}
+
+// Nodes returns the positions visited, including leaves
+func (n NodeStats) Nodes() int {
+	return n.nodes
+}
+
+// PV returns the nodes whose score fell inside the alpha beta window
+func (n NodeStats) PV() int {
+	return n.pv
+}
+
+// Cut returns the nodes where a move caused a beta cutoff
+func (n NodeStats) Cut() int {
+	return n.cut
+}
+
+// All returns the nodes where no move raised alpha
+func (n NodeStats) All() int {
+	return n.all
+}
+
+// BranchingFactor returns the nodes of this depth for each node of the previous one, 0 for the first depth
+func (n NodeStats) BranchingFactor() float64 {
+	return n.branchingFactor
+}
+
+func (n NodeStats) String() string {
+	return fmt.Sprintf("nodes %d ebf %.2f pv %d cut %d all %d first move cuts %.1f%%",
+		n.nodes, n.branchingFactor, n.pv, n.cut, n.all, n.FirstMoveCutPercentage())
+}
+
+// reset prepares the search for a new iteration. The table is only created the first time: its entries record the
+// depth they were searched to, so deeper iterations can still use them, at least to search their best moves first.
+func (s *search) reset(maxMemoryMB int) {
+	if s.table == nil { s.table = NewTranspositionTable(maxMemoryMB) }
+	s.stats = NodeStats{}
+}
+
+// timeUp tells whether the search ran out of time; once it does, every node returns right away and their
+// results must be ignored
+func (s *search) timeUp() bool {
+	if !s.aborted && !s.deadline.IsZero() && !time.Now().Before(s.deadline) { s.aborted = true }
+	return s.aborted
+}
+
+// negamax returns the best move for the side to move in position, and its score from that side's point of view;
+// there's no best move at the leaves. The moves are played in position with MakeMove, and taken back before
+// returning, so it's left as it was.
+func (s *search) negamax(position *Position, alpha, beta int, maxDepth int) (bestMove moveKey, bestScore int) {
+
+	if s.timeUp() { return }
+	s.nodes ++
+	s.stats.nodes ++
+	if selfCheckEnabled && s.nodes % selfCheckInterval == 0 { s.selfCheck(*position) }
+
+	// going back to a position of the game is scored as the draw it leads to, if repeated once more: so the side
+	// that's worse looks for repetitions, and the side that's better avoids them
+	if InsufficientMaterial(position.board) || s.history[position.key()] > 0 {
+		bestScore = drawScore
+		return
+	}
+
+	if maxDepth == 0 {
+		bestScore = s.quiescence(position, alpha, beta, s.quiescenceDepth)
+		return
+	}
+	
+	// a score from the table can be used if it was searched at least as deep, and its bound says enough for this
+	// window; otherwise its best move is still the best guess, so it's searched first
+	hash := ZobristHash(*position)
+	entry, cached := s.table.probe(hash)
+	if cached && int(entry.depth) >= maxDepth {
+		score := scoreFromTable(int(entry.score), maxDepth)
+		if entry.bound == Bound_Exact || (entry.bound == Bound_Lower && score >= beta) || (entry.bound == Bound_Upper && score <= alpha) {
+			return entry.move, score
+		}
+	}
+
+	moves := searchMoves(*position)
+
+	if len(moves) == 0 {
+		bestScore = terminalScore(*position, maxDepth)
+		return
+	}
+	moves = orderMoves(moves)
+	if cached { moves = moveToFront(moves, entry.move) }
+
+	var score int
+	originalAlpha := alpha
+	bestScore = lowestScore
+	for i, move := range moves {
+		
+		undo := position.MakeMove(move)
+		_, score = s.negamax(position, -beta, -alpha, maxDepth - 1)
+		position.UnmakeMove(undo)
+		if s.aborted { return }
+		
+		score = - score
+		if score > bestScore {
+			bestScore = score
+			bestMove = move.key()
+		}
+		
+		alpha = int(math.Max(float64(alpha), float64(score)))
+		if alpha >= beta {
+			s.stats.cut ++
+			if i == 0 { s.stats.firstMoveCuts ++ }
+			break
+		}
+	}
+
+	bound := Bound_Exact
+	switch {
+	case bestScore >= beta:
+		bound = Bound_Lower
+	case bestScore <= originalAlpha:
+		bound = Bound_Upper
+		s.stats.all ++
+	default:
+		s.stats.pv ++
+	}
+	s.table.store(hash, maxDepth, scoreToTable(bestScore, maxDepth), bound, bestMove)
+	
+	return
+}
+
+// quiescence scores a position at the end of the search: instead of evaluating it in the middle of an exchange,
+// where the score would be off by a piece, it keeps searching captures and promotions until the position is quiet.
+// The side to move can stand pat, keeping the static score, when capturing doesn't pay off; except in check, where
+// every evasion is searched. Captures that lose material in the static exchange are left out, as standing pat is
+// always better than them. Together they keep the search small; depthLeft is only a safety limit. As in negamax,
+// the moves are made and taken back in position.
+func (s *search) quiescence(position *Position, alpha, beta int, depthLeft int) int {
+	if s.timeUp() { return 0 }
+	if depthLeft == 0 { return s.evaluate(*position) }
+	s.nodes ++
+	s.stats.nodes ++
+
+	var moves []MoveInfo
+	best := lowestScore
+	inCheck := givesCheck(position.board, !position.sideToMove)
+	if inCheck {
+		moves = searchMoves(*position)
+		// past the end of the search, depth left counts down below 0, so later mates score lower
+		if len(moves) == 0 { return terminalScore(*position, depthLeft - s.quiescenceDepth) }
+	} else {
+		best = s.evaluate(*position)
+		if best >= beta { return best }
+		moves = searchCaptures(*position)
+	}
+	moves = orderMoves(moves)
+	alpha = int(math.Max(float64(alpha), float64(best)))
+
+	for _, m := range moves {
+		if !inCheck && m.captured != Piece_Empty && !m.Is(MoveFlag_EnPassant) && StaticExchange(position.board, m.from, m.to) < 0 { continue }
+		undo := position.MakeMove(m)
+		score := - s.quiescence(position, -beta, -alpha, depthLeft - 1)
+		position.UnmakeMove(undo)
+		if s.aborted { return 0 }
+
+		if score > best { best = score }
+		alpha = int(math.Max(float64(alpha), float64(score)))
+		if alpha >= beta { break }
+	}
+	return best
+}
+
+// score searches a position, and returns its score for the side to move
+func (s *search) score(position Position, maxDepth int, maxMemoryMB int) int {
+	s.reset(maxMemoryMB)
+	_, score := s.negamax(&position, lowestScore, biggestScore, maxDepth)
+	return score
+}
+
+// Negamax searches the best move using a new transposition table with the default memory budget; to search more
+// than once, NegamaxWithTable can keep the table
+func Negamax(position Position, maxDepth int) (bestMove Board, bestScore int) {
+	return NegamaxWithTable(position, maxDepth, NewTranspositionTable(DefaultSearchOptions().maxMemoryMB))
+}
+
+// NegamaxWithTable is Negamax with a given table; when there is no best move, as at depth 0, bestMove is the board
+// of position
+func NegamaxWithTable(position Position, maxDepth int, transpositionTable *TranspositionTable) (bestMove Board, bestScore int) {
+	s := search{ table: transpositionTable, evaluate: EvaluateBoard, quiescenceDepth: DefaultSearchOptions().quiescenceDepth }
+	move, bestScore := s.negamax(&position, lowestScore, biggestScore, maxDepth)
+	if m, ok := findMoveKey(position, move); ok { return m.board, bestScore }
+	return position.board, bestScore
+}
+
+// SearchResult is what SearchBestMove found
+type SearchResult struct {
+	bestMove Board
+	score int
+	depth int // the deepest search completed, 0 if not even the depth 1 search could be completed
+	swindle bool // whether bestMove was picked by the swindle mode
+	easyMove bool // whether the search stopped early because bestMove was obvious
+	memoryUsage, memoryBudget int // of the transposition table used by the last search
+	table *TranspositionTable // used by the last search, nil if not even the first search could start
+	nodes int // positions visited, counting all depths
+	stats NodeStats // of the deepest search completed
+}
+
+// BestMove returns the move found, as the board after it; the zero Board if there are no legal moves
+func (r SearchResult) BestMove() Board {
+	return r.bestMove
+}
+
+// Score returns the score of the best move, from the point of view of the side to move
+func (r SearchResult) Score() int {
+	return r.score
+}
+
+// Depth returns the deepest search completed, 0 if not even the depth 1 search could be completed
+func (r SearchResult) Depth() int {
+	return r.depth
+}
+
+// Swindled tells whether the best move was picked by the swindle mode
+func (r SearchResult) Swindled() bool {
+	return r.swindle
+}
+
+// EasyMove tells whether the search stopped early because the best move was obvious
+func (r SearchResult) EasyMove() bool {
+	return r.easyMove
+}
+
+// Nodes returns the positions visited, counting all depths
+func (r SearchResult) Nodes() int {
+	return r.nodes
+}
+
+// Stats returns the node statistics of the deepest search completed
+func (r SearchResult) Stats() NodeStats {
+	return r.stats
+}
+
+// MemoryUsage returns the memory used by the transposition table, and its budget, in bytes
+func (r SearchResult) MemoryUsage() (used, budget int) {
+	return r.memoryUsage, r.memoryBudget
+}
+
+// SearchInfo reports the progress of SearchBestMove, once per depth completed
+type SearchInfo struct {
+	depth int
+	score int // from the point of view of the side to move
+	nodes int // positions visited so far, counting all depths
+	elapsed time.Duration // since the search started
+	pv []string // principal variation, in coordinate notation
+	stats NodeStats // of this depth only
+}
+
+// Depth returns the depth completed
+func (i SearchInfo) Depth() int {
+	return i.depth
+}
+
+// Score returns the score of the best move at this depth, from the point of view of the side to move
+func (i SearchInfo) Score() int {
+	return i.score
+}
+
+// Nodes returns the positions visited so far, counting all depths
+func (i SearchInfo) Nodes() int {
+	return i.nodes
+}
+
+// Elapsed returns the time since the search started
+func (i SearchInfo) Elapsed() time.Duration {
+	return i.elapsed
+}
+
+// PV returns the principal variation, in coordinate notation, starting with the best move
+func (i SearchInfo) PV() []string {
+	return i.pv
+}
+
+// Stats returns the node statistics of this depth only
+func (i SearchInfo) Stats() NodeStats {
+	return i.stats
+}
+
+// NodesPerSecond returns the search speed
+func (i SearchInfo) NodesPerSecond() int {
+	if i.elapsed <= 0 { return 0 }
+	return int(float64(i.nodes) / i.elapsed.Seconds())
+}
+
+// String formats the information like an UCI info line
+func (i SearchInfo) String() string {
+	score := fmt.Sprint("cp ", ToCentipawns(i.score))
+	if i.score >= mateThreshold || i.score <= - mateThreshold {
+		// a mate found with depthLeft plies still to search scores checkMateScore + depthLeft; depthLeft is below 0
+		// for mates found by the quiescence search
+		plies := i.depth - (int(math.Abs(float64(i.score))) - checkMateScore)
+		moves := (plies + 1) / 2
+		if i.score < 0 { moves = - moves }
+		score = fmt.Sprint("mate ", moves)
+	}
+	return fmt.Sprintf("info depth %d score %s nodes %d nps %d time %d pv %s",
+		i.depth, score, i.nodes, i.NodesPerSecond(), i.elapsed.Milliseconds(), strings.Join(i.pv, " "))
+}
+
+// principalVariation follows the best moves stored in the transposition table, starting with bestMove. It can be
+// shorter than depth when the entries of the line were replaced by other positions.
+func (s *search) principalVariation(position Position, bestMove Board, depth int) (pv []string) {
+	move := bestMove
+	for i := 0; i < depth; i ++ {
+		pv = append(pv, DescribeMove(position, move))
+		position = position.Play(move)
+
+		var ok bool
+		move, ok = s.table.BestMove(position)
+		if !ok { break }
+	}
+	return
+}
+
+// moveToFront returns moves with the one with the given key in the first place, if it's there
+func moveToFront(moves []MoveInfo, move moveKey) []MoveInfo {
+	sorted := make([]MoveInfo, 0, len(moves))
+	for _, m := range moves {
+		if m.key() == move { sorted = append(sorted, m) }
+	}
+	for _, m := range moves {
+		if m.key() != move { sorted = append(sorted, m) }
+	}
+	return sorted
+}
+
+// captureOrder is the key moves are sorted by: captures and promotions by most valuable victim (the promoted piece
+// counts as one), then least valuable attacker, all of them before quiet moves, which are 0. The king counts as the
+// most valuable attacker.
+func captureOrder(m MoveInfo) int {
+	victim := pieceScoreMap[m.captured] + pieceScoreMap[m.promotion]
+	if victim == 0 { return 0 }
+	attacker := int(math.Min(float64(pieceScoreMap[m.piece]), 10))
+	return victim * 16 - attacker
+}
+
+// orderMoves sorts moves so the ones most likely to be best are searched first, which is what alpha beta needs to
+// prune: captures first, by captureOrder, and quiet moves keep the order they were generated in
+func orderMoves(moves []MoveInfo) []MoveInfo {
+	sort.SliceStable(moves, func(i, j int) bool { return captureOrder(moves[i]) > captureOrder(moves[j]) })
+	return moves
+}
+
+// searchRoot searches the moves available in position at a given depth. If time runs out, complete is false and
+// bestMove is the best of the moves searched so far (found is false if there was none).
+func (s *search) searchRoot(position Position, moves []MoveInfo, depth int) (bestMove MoveInfo, bestScore int, found, complete bool) {
+	alpha := lowestScore
+	bestScore = lowestScore
+
+	for _, move := range moves {
+		undo := position.MakeMove(move)
+		_, score := s.negamax(&position, lowestScore, - alpha, depth - 1)
+		position.UnmakeMove(undo)
+		if s.aborted { return }
+
+		score = - score
+		if !found || score > bestScore {
+			bestMove, bestScore, found = move, score, true
+		}
+		alpha = int(math.Max(float64(alpha), float64(score)))
+	}
+
+	complete = true
+	return
+}
+
+// isRecapture tells whether move takes back on the square where the opponent just captured, going from previous
+// to position
+func isRecapture(previous Board, position Position, move Board) bool {
+	color := position.sideToMove
+	for _, pos := range AllSquares() {
+		before := GetBoardAt(previous, pos)
+		if before.piece == Piece_Empty || before.color != color { continue }
+
+		now := GetBoardAt(position.board, pos)
+		if now.piece == Piece_Empty || now.color == color { continue }
+
+		after := GetBoardAt(move, pos)
+		return after.piece != Piece_Empty && after.color == color
+	}
+	return false
+}
+
+// SearchBestMove searches one ply deeper at a time, until options.depth is reached or the time for the move runs
+// out. As long as there is a legal move, it always returns one before the deadline: if not even the first search
+// can be completed, it returns the best of the moves it had time to look at, or just the first legal move.
+func SearchBestMove(position Position, options SearchOptions) (result SearchResult) {
+	if options.observer != nil { defer func() { options.observer.OnFinish(result) }() }
+
+	s := search{ history: options.history, evaluate: options.evaluate, quiescenceDepth: options.quiescenceDepth, table: options.table }
+	if s.evaluate == nil { s.evaluate = EvaluateBoard }
+	if s.table != nil { s.table.newSearch() }
+	if options.moveTime > 0 {
+		s.deadline = time.Now().Add(options.moveTime - options.moveOverhead)
+	}
+
+	moves := orderMoves(GenerateMoves(position))
+	if len(moves) == 0 { return }
+	result.bestMove = moves[0].board
+
+	easyMove := options.easyMove && options.moveTime > 0
+	if easyMove && len(moves) == 1 {
+		result.easyMove = true
+		return
+	}
+	stable := true // whether every depth found the same best move
+
+	start := time.Now()
+	for depth := int(math.Max(1, float64(options.startDepth))); depth <= options.depth; depth ++ {
+		s.reset(options.maxMemoryMB)
+
+		move, score, found, complete := s.searchRoot(position, moves, depth)
+		if complete || (result.depth == 0 && found) {
+			result.bestMove, result.score = move.board, score
+			result.memoryUsage, result.memoryBudget = s.table.MemoryUsage(), s.table.MemoryBudget()
+			result.table = s.table
+		}
+		if !complete { break }
+
+		changed := result.depth == 0 || move.key() != moves[0].key()
+		if result.depth > 0 && changed { stable = false }
+		if result.depth > 0 && result.stats.nodes > 0 {
+			s.stats.branchingFactor = float64(s.stats.nodes) / float64(result.stats.nodes)
+		}
+		result.depth, result.stats = depth, s.stats
+		moves = moveToFront(moves, move.key())
+
+		if options.observer != nil {
+			info := SearchInfo{ depth, score, s.nodes, time.Since(start), s.principalVariation(position, move.board, depth), s.stats }
+			options.observer.OnIterationComplete(info)
+			if changed { options.observer.OnBestMoveChange(info) }
+		}
+
+		if easyMove && depth >= 2 && stable && isRecapture(options.previousBoard, position, move.board) {
+			result.easyMove = true
+			break
+		}
+	}
+
+	result.nodes = s.nodes
+
+	swindleTime := options.opponentTime == 0 || options.opponentTime < swindleTimePressure
+	if options.swindle && swindleTime && !result.easyMove && result.depth == options.depth {
+		move, score := s.swindleMove(position, result.bestMove, result.score, options)
+		if move != result.bestMove {
+			result.bestMove, result.score, result.swindle = move, score, true
+		}
+	}
+
+	return
+}
+
+// ScoreMoves searches every move available in board to the given depth, and returns them sorted from best to worst.
+// table can be kept from earlier searches of the same position, or nil to use a new one.
+func ScoreMoves(position Position, depth int, table *TranspositionTable) []BoardScore {
+	moves := LegalMoves(position)
+	if table == nil { table = NewTranspositionTable(DefaultSearchOptions().maxMemoryMB) }
+	table.newSearch()
+
+	scores := make([]BoardScore, 0, len(moves))
+	for _, move := range moves {
+		_, score := NegamaxWithTable(position.Play(move), depth - 1, table)
+		scores = append(scores, BoardScore{ move, - score })
+	}
+
+	sort.SliceStable(scores, func(i, j int) bool { return scores[i].score > scores[j].score })
+	return scores
+}
+
+// swindle settings, scores are in the same units returned by EvaluateBoard
+var swindleTimePressure = 2 * time.Minute // with a clock, only try to swindle when the opponent has less time than this
+var swindleLosingScore = - 6 // only try to swindle when the best line loses at least this much
+var swindleMargin = 4 // how much worse than the best move a swindle move is allowed to be
+var swindleTrapGap = 4 // how much worse than the opponent's best reply a reply must be to count as a mistake
+
+// opponentErrorRate estimates how likely the opponent is to go wrong after move is played: the fraction of
+// replies that look good with a static evaluation but are clearly worse than the best reply when searched deeper.
+func (s *search) opponentErrorRate(position Position, move Board, deepDepth int, maxMemoryMB int) float64 {
+	opponentPosition := position.Play(move)
+	replies := LegalMoves(opponentPosition)
+	if len(replies) < 2 { return 0 }
+
+	shallowScores := make([]int, len(replies))
+	deepScores := make([]int, len(replies))
+	bestShallow, bestDeep := lowestScore, lowestScore
+
+	// scores are from the opponent's point of view
+	for i, reply := range replies {
+		shallowScores[i] = - EvaluateBoard(opponentPosition.Play(reply))
+		deepScores[i] = - s.score(opponentPosition.Play(reply), deepDepth, maxMemoryMB)
+		if s.aborted { return 0 }
+
+		if shallowScores[i] > bestShallow { bestShallow = shallowScores[i] }
+		if deepScores[i] > bestDeep { bestDeep = deepScores[i] }
+	}
+
+	attractive, traps := 0, 0
+	for i := range replies {
+		if shallowScores[i] < bestShallow - swindleMargin { continue }
+		attractive ++
+		if deepScores[i] <= bestDeep - swindleTrapGap { traps ++ }
+	}
+
+	if attractive == 0 { return 0 }
+	return float64(traps) / float64(attractive)
+}
+
+// swindleMove is used in lost positions: among the moves that aren't much worse than bestScore, it picks the one
+// that sets the most traps for the opponent, instead of the objectively best but simple move. If time runs out,
+// it keeps the best choice found so far.
+func (s *search) swindleMove(position Position, bestMove Board, bestScore int, options SearchOptions) (move Board, score int) {
+	move, score = bestMove, bestScore
+	maxDepth := options.depth
+	if bestScore > swindleLosingScore || maxDepth < 2 { return }
+
+	moves := LegalMoves(position)
+
+	bestRate := s.opponentErrorRate(position, bestMove, maxDepth - 2, options.maxMemoryMB)
+	for _, m := range moves {
+		if m == bestMove { continue }
+
+		moveScore := - s.score(position.Play(m), maxDepth - 1, options.maxMemoryMB)
+		if s.aborted { return }
+		if moveScore < bestScore - swindleMargin { continue }
+
+		rate := s.opponentErrorRate(position, m, maxDepth - 2, options.maxMemoryMB)
+		if s.aborted { return }
+		if rate > bestRate {
+			bestRate = rate
+			move, score = m, moveScore
+		}
+	}
+
+	return
+}
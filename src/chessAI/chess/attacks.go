@@ -0,0 +1,156 @@
+package chess
+
+import "math"
+
+// rayDirections holds the directions used by sliding pieces; the first four are the rock ones, the others the bishop ones
+var rayDirections = []Move{ Move{0, 1}, Move{0, -1}, Move{1, 0}, Move{-1, 0}, Move{1, 1}, Move{-1, -1}, Move{1, -1}, Move{-1, 1} }
+
+var knightJumps = []Move{ Move{-2, -1}, Move{-1, -2}, Move{2, 1}, Move{1, 2}, Move{-2, 1}, Move{-1, 2}, Move{2, -1}, Move{1, -2} }
+
+func sign0(x int) int {
+	if x > 0 { return 1 }
+	if x < 0 { return -1 }
+	return 0
+}
+
+// squaresBetween returns the squares strictly between a and b, if they are on the same rank, file or diagonal
+func squaresBetween(a, b Square) (squares []Square, aligned bool) {
+	dx, dy := b.x - a.x, b.y - a.y
+	if a == b || (dx != 0 && dy != 0 && dx != dy && dx != -dy) { return }
+
+	step := Move{ sign0(dx), sign0(dy) }
+	for pos := SquareAdd(a, step); pos != b; pos = SquareAdd(pos, step) {
+		squares = append(squares, pos)
+	}
+	return squares, true
+}
+
+// getAttackers returns the pieces of color byColor that attack a square, looking from the square outwards instead
+// of generating the moves of every enemy piece
+func getAttackers(board Board, target Square, byColor PieceColor) []Square {
+	attackers := []Square{}
+	isAttacker := func(pos Square, pieces ...Piece) bool {
+		if !SquareInBoard(pos) { return false }
+		info := GetBoardAt(board, pos)
+		if info.piece == Piece_Empty || info.color != byColor { return false }
+		for _, piece := range pieces {
+			if info.piece == piece { return true }
+		}
+		return false
+	}
+
+	for _, jump := range knightJumps {
+		pos := SquareAdd(target, jump)
+		if isAttacker(pos, Piece_Knight) { attackers = append(attackers, pos) }
+	}
+
+	// pawns attack diagonally forward, so they are behind the target from their point of view
+	pawnDirection := 1
+	if byColor == PieceColor_White { pawnDirection = -1 }
+	for _, dx := range []int{ -1, 1 } {
+		pos := Square{ target.x + dx, target.y - pawnDirection }
+		if isAttacker(pos, Piece_Pawn) { attackers = append(attackers, pos) }
+	}
+
+	for i, dir := range rayDirections {
+		slider := Piece_Rock
+		if i >= 4 { slider = Piece_Bishop }
+
+		pos := SquareAdd(target, dir)
+		if isAttacker(pos, Piece_King) { attackers = append(attackers, pos) }
+
+		for ; SquareInBoard(pos); pos = SquareAdd(pos, dir) {
+			if GetBoardAt(board, pos).piece == Piece_Empty { continue }
+			if isAttacker(pos, slider, Piece_Queen) { attackers = append(attackers, pos) }
+			break
+		}
+	}
+
+	return attackers
+}
+
+// resolvesCheck tells whether a move gets rid of the check given by a single checker, by capturing it (en passant
+// too) or by putting a piece in one of the blocks squares
+func resolvesCheck(m MoveInfo, checker Square, blocks []Square) bool {
+	if m.to == checker || (m.Is(MoveFlag_EnPassant) && checker == Square{ m.to.x, m.from.y }) { return true }
+
+	for _, pos := range blocks {
+		if m.to == pos { return true }
+	}
+	return false
+}
+
+// getEvasions returns the legal moves of color when its king is in check: king moves, captures of the checking
+// piece and interpositions. Everything else is discarded before the (slow) legality filter.
+func getEvasions(board Board, color PieceColor, kingPos Square, checkers []Square, quickMode bool) []MoveInfo {
+	filterCheckMoves := false
+	evasions := []MoveInfo{}
+
+	var blocks []Square
+	if len(checkers) == 1 { blocks, _ = squaresBetween(kingPos, checkers[0]) }
+
+	for _, pos := range GetPiecesByColor(board, color) {
+		info := GetBoardAt(board, pos)
+
+		// in double check, only the king can move
+		if info.piece != Piece_King && len(checkers) > 1 { continue }
+
+		for _, move := range pieceMoves(board, pos, info, filterCheckMoves, quickMode) {
+			if info.piece == Piece_King || resolvesCheck(move, checkers[0], blocks) {
+				evasions = append(evasions, move)
+			}
+		}
+	}
+
+	return removeCheckMoves(board, evasions, color)
+}
+
+// StaticExchange estimates the material won by capturing on target with the piece at from, if both sides keep
+// recapturing there with their least valuable piece while it pays off. Pins are not taken into account.
+func StaticExchange(board Board, from, target Square) int {
+	gains := []int{}
+	attacker := from
+	color := GetBoardAt(board, from).color
+
+	for {
+		gains = append(gains, pieceScoreMap[GetBoardAt(board, target).piece])
+		SetBoardAt(&board, target, GetBoardAt(board, attacker))
+		SetBoardAt(&board, attacker, EmptyPieceInfo)
+		color = !color
+
+		// removing the attacker lets pieces behind it attack through
+		attackers := getAttackers(board, target, color)
+		if len(attackers) == 0 { break }
+		attacker = leastValuable(board, attackers)
+	}
+
+	// the first capture is the one being tested, every recapture after it is optional
+	next := 0
+	for i := len(gains) - 1; i >= 1; i -- {
+		next = int(math.Max(0, float64(gains[i] - next)))
+	}
+	return gains[0] - next
+}
+
+// givesCheck tells whether the king of the color that didn't move is under attack in board
+func givesCheck(board Board, color PieceColor) bool {
+	kingPos := GetPieces(board, Piece_King, !color)[0]
+	return len(getAttackers(board, kingPos, color)) != 0
+}
+
+// GetCheckingMoves returns the legal moves of color that give check, both direct and discovered.
+// Checking for check is cheap compared to checking for legality, so legality is only checked for the moves that
+// give check.
+func GetCheckingMoves(board Board, color PieceColor) []Board {
+	filterCheckMoves := false
+	quickMode := false
+	checks := []MoveInfo{}
+
+	for _, pos := range GetPiecesByColor(board, color) {
+		info := GetBoardAt(board, pos)
+		for _, move := range pieceMoves(board, pos, info, filterCheckMoves, quickMode) {
+			if givesCheck(moveBoard(board, move), color) { checks = append(checks, move) }
+		}
+	}
+	return moveBoards(board, removeCheckMoves(board, checks, color))
+}
@@ -0,0 +1,239 @@
+package chess
+
+import "math/bits"
+import "strings"
+
+const PieceStatusBits = 3
+const BitsPerSquare = PieceStatusBits + 2
+
+/*
+
+Every board square is numbered this way:
+
+ 0  1  2  3  4  5  6  7
+ 8  9 10 11 12 13 14 15
+16 17 ...
+...
+
+A uint64 in Board contains one bit for each of the 64 squares,
+in that order.
+
+The first PieceStatusBits bits for each square represent the current square status.
+This status uses the values in PieceStatus.
+
+The remaining bits are used for:
+
+- one bit for storing the PieceStatus value (can the king do a castling move? etc.)
+- one bit for storing the PieceColor value
+
+*/
+
+type Board [BitsPerSquare]uint64;
+
+type Piece uint8
+
+const (
+	Piece_Empty Piece = iota
+	Piece_Pawn
+	Piece_Rock
+	Piece_Knight
+	Piece_Bishop
+	Piece_King
+	Piece_Queen
+)
+
+var pieceNamesMap = map[Piece]string {
+	Piece_Empty : "Empty", Piece_Pawn : "Pawn", Piece_Rock : "Rock", Piece_Bishop : "Bishop", Piece_Knight : "Knight", Piece_Queen : "Queen", Piece_King : "King",
+}
+
+func (p Piece) String() string {
+	return pieceNamesMap[p]
+}
+
+type PieceStatus bool
+
+const (
+	PieceStatus_Default PieceStatus = false // initial status: pawn can't be captured in en-passant, rock / king can do castling
+	PieceStatus_EnPassantAllowed = true // pawn can be captured using en-passant move
+	PieceStatus_CastlingNotAllowed = true // rock or king not allowed to do castling
+)
+
+type PieceColor bool
+
+const (
+	PieceColor_White PieceColor = true
+	PieceColor_Black = false
+)
+
+type PieceInfo struct {
+	piece Piece
+	status PieceStatus
+	color PieceColor
+}
+
+var EmptyPieceInfo = PieceInfo{ Piece_Empty, PieceStatus_Default, PieceColor_White }
+
+// NewPieceInfo returns the contents of a square, for SetBoardAt
+func NewPieceInfo(piece Piece, status PieceStatus, color PieceColor) PieceInfo {
+	return PieceInfo{ piece, status, color }
+}
+
+// Piece returns the piece on the square, Piece_Empty if there is none
+func (p PieceInfo) Piece() Piece {
+	return p.piece
+}
+
+// Status tells whether a pawn can be captured en-passant, or a king or rock can't castle anymore
+func (p PieceInfo) Status() PieceStatus {
+	return p.status
+}
+
+// Color returns the color of the piece; for empty squares, the color of the last piece that left them
+func (p PieceInfo) Color() PieceColor {
+	return p.color
+}
+
+// String writes the piece as its FEN letter, uppercase for white, like "N" or "q"; empty for an empty square
+func (p PieceInfo) String() string {
+	letter := pieceLetterMap[p.piece]
+	if p.color == PieceColor_White { letter = strings.ToUpper(letter) }
+	return letter
+}
+
+func (p PieceColor) String() string {
+	if p == PieceColor_White { return "White" }
+	return "Black"
+}
+
+func BoolToInt(b bool) uint64 {
+	if b {
+		return 1
+	}
+	return 0
+}
+
+// occupiedBits returns a bit per occupied square, in the same order used by Board
+func occupiedBits(board Board) uint64 {
+	var occupied uint64
+	for i := 0; i < PieceStatusBits; i ++ {
+		occupied |= board[i]
+	}
+	return occupied
+}
+
+// pieceBits returns a bit per square holding the given piece and color, in the same order used by Board
+func pieceBits(board Board, piece Piece, color PieceColor) uint64 {
+	found := occupiedBits(board)
+	for i := uint64(0); i < PieceStatusBits; i ++ {
+		if GetBitValue(uint64(piece), i) == 1 {
+			found &= board[i]
+		} else {
+			found &^= board[i]
+		}
+	}
+
+	colorBits := board[PieceStatusBits + 1]
+	if color == PieceColor_Black { colorBits = ^colorBits }
+	return found & colorBits
+}
+
+// countPieces returns the number of pieces of a given color on the board
+func countPieces(board Board, color PieceColor) int {
+	colorBits := board[PieceStatusBits + 1]
+	if color == PieceColor_Black { colorBits = ^colorBits }
+	return bits.OnesCount64(occupiedBits(board) & colorBits)
+}
+
+func GetBitValue(bits uint64, pos uint64) uint64 {
+	return (bits >> pos) & 1
+}
+
+func SetBitValue(bits, pos, value uint64) uint64 {
+	return (bits &^ (1 << pos)) | (value << pos)
+}
+
+// GetBoardAt gives information about a piece in a given position
+func GetBoardAt(board Board, pos Square) (info PieceInfo) {
+	var value, bitidx uint64
+
+	if !SquareInBoard(pos) { panic("wrong position") }
+
+	bitidx = uint64(pos.Index())
+
+	// value[bit0] = board[0][bit pos], value[bit1] = board[1][bit pos], ...
+	for i := uint64(0); i < PieceStatusBits; i ++ {
+		value |= GetBitValue(board[i], bitidx) << i
+	}
+
+	info.piece = Piece(value)
+	info.status = 1 == GetBitValue(board[PieceStatusBits], bitidx)
+	info.color = 1 == GetBitValue(board[PieceStatusBits + 1], bitidx)
+
+	return
+}
+
+// SetBoardAt modifies a specific position of a board
+func SetBoardAt(board *Board, pos Square, info PieceInfo) {
+
+	if !SquareInBoard(pos) { panic("wrong position") }
+
+	bitidx := uint64(pos.Index())
+
+	for i := uint64(0); i < PieceStatusBits; i ++ {
+		(*board)[i] = SetBitValue((*board)[i], bitidx, GetBitValue(uint64(info.piece), i))
+	}
+
+	(*board)[PieceStatusBits] = SetBitValue((*board)[PieceStatusBits], bitidx, BoolToInt(bool(info.status)))
+	(*board)[PieceStatusBits + 1] = SetBitValue((*board)[PieceStatusBits + 1], bitidx, BoolToInt(bool(info.color)))
+}
+
+func GetPieces(board Board, piece Piece, color PieceColor) []Square {
+	return SquaresFromBits(pieceBits(board, piece, color))
+}
+
+func GetPiecesByColor(board Board, color PieceColor) []Square {
+	colorBits := board[PieceStatusBits + 1]
+	if color == PieceColor_Black { colorBits = ^colorBits }
+	return SquaresFromBits(occupiedBits(board) & colorBits)
+}
+
+// ForEachPiece calls f for every occupied square, in the same order as AllSquares, visiting only the occupied ones
+func ForEachPiece(board Board, f func(Square, PieceInfo)) {
+	for occupied := occupiedBits(board); occupied != 0; occupied &= occupied - 1 {
+		pos := SquareFromIndex(bits.TrailingZeros64(occupied))
+		f(pos, GetBoardAt(board, pos))
+	}
+}
+
+func fillInitialBoardSide(board Board, piecesRow, pawnsRow int, color PieceColor, testBoard bool) Board {
+	for i := 0; i < 8; i ++ {
+		SetBoardAt(&board, Square{i, pawnsRow}, PieceInfo{ Piece_Pawn, PieceStatus_Default, color })
+	}
+
+	SetBoardAt(&board, Square{0, piecesRow}, PieceInfo{ Piece_Rock, PieceStatus_Default, color })
+	SetBoardAt(&board, Square{7, piecesRow}, PieceInfo{ Piece_Rock, PieceStatus_Default, color })
+
+	SetBoardAt(&board, Square{4, piecesRow}, PieceInfo{ Piece_King, PieceStatus_Default, color })
+
+	if !testBoard {
+		SetBoardAt(&board, Square{1, piecesRow}, PieceInfo{ Piece_Knight, PieceStatus_Default, color })
+		SetBoardAt(&board, Square{6, piecesRow}, PieceInfo{ Piece_Knight, PieceStatus_Default, color })
+
+		SetBoardAt(&board, Square{2, piecesRow}, PieceInfo{ Piece_Bishop, PieceStatus_Default, color })
+		SetBoardAt(&board, Square{5, piecesRow}, PieceInfo{ Piece_Bishop, PieceStatus_Default, color })
+
+		SetBoardAt(&board, Square{3, piecesRow}, PieceInfo{ Piece_Queen, PieceStatus_Default, color })
+	}
+
+	return board
+}
+
+func InitialBoard(testBoard bool) Board {
+	var board Board
+
+	board = fillInitialBoardSide(board, 0, 1, PieceColor_Black, testBoard)
+	board = fillInitialBoardSide(board, 7, 6, PieceColor_White, testBoard)
+
+	return board
+}
+
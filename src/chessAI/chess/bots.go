@@ -0,0 +1,45 @@
+//go:build !rulesonly
+
+package chess
+
+import "sort"
+import "strings"
+
+// Bot is a ready-made computer opponent, with its own way of searching and evaluating
+type Bot struct {
+	name string
+	description string
+	configure func(options *SearchOptions)
+}
+
+var bots = map[string]Bot {
+	"default" : { "default", "the usual search settings", func(options *SearchOptions) {} },
+	"greedy" : { "greedy", "only counts material, so it grabs everything it can", func(options *SearchOptions) {
+		options.depth, options.evaluate = 2, MaterialEvaluation
+	} },
+	"anaconda" : { "anaconda", "searches deep and takes its time, never playing a move right away", func(options *SearchOptions) {
+		options.depth, options.easyMove = 5, false
+	} },
+	"swindler" : { "swindler", "when losing, sets traps instead of playing the best moves", func(options *SearchOptions) {
+		options.swindle = true
+	} },
+}
+
+// BotNames returns the names of the bots, sorted
+func BotNames() []string {
+	names := []string{}
+	for name := range bots {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// DescribeBots lists the bots, one per line
+func DescribeBots() string {
+	lines := []string{}
+	for _, name := range BotNames() {
+		lines = append(lines, "  " + name + ": " + bots[name].description)
+	}
+	return strings.Join(lines, "\n")
+}
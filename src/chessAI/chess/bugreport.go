@@ -0,0 +1,38 @@
+//go:build !rulesonly
+
+package chess
+
+import "fmt"
+import "os"
+import "runtime/debug"
+import "strings"
+import "time"
+
+// bugReportFile is where SafeSearchGame writes what it knows when the search crashes
+var bugReportFile = "chessAI-bug-report.txt"
+
+// SafeSearchGame is SearchGame for games, where a bug in the search shouldn't lose the game. If the search panics,
+// it writes a bug report with the position, how the game got there and the search options, and falls back to the
+// first legal move; err tells what went wrong then.
+func (e *Engine) SafeSearchGame(game *Game) (result SearchResult, err error) {
+	options := e.gameOptions(game)
+	position, history := game.position, game.history
+	defer func() {
+		recovered := recover()
+		if recovered == nil { return }
+
+		report := fmt.Sprintf("time: %s\nerror: %v\nposition: %s\nstart: %s\nmoves: %s\noptions: %+v\n\n%s",
+			time.Now().Format(time.RFC3339), recovered, ToFEN(position), ToFEN(history.start),
+			strings.Join(history.moves, " "), options, debug.Stack())
+		if writeErr := os.WriteFile(bugReportFile, []byte(report), 0644); writeErr != nil {
+			err = fmt.Errorf("internal error in the search: %v, and can't write bug report: %v", recovered, writeErr)
+		} else {
+			err = fmt.Errorf("internal error in the search: %v, bug report written to %s", recovered, bugReportFile)
+		}
+
+		result = SearchResult{}
+		if moves := LegalMoves(position); len(moves) > 0 { result.bestMove = moves[0] }
+	}()
+
+	return SearchBestMove(position, options), nil
+}
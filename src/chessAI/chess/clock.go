@@ -0,0 +1,105 @@
+package chess
+
+import "fmt"
+import "time"
+
+// Clock keeps the time left for each player
+type Clock struct {
+	initial map[PieceColor]time.Duration // at the start of the game, for the time control
+	remaining map[PieceColor]time.Duration
+	increment time.Duration // added after every move
+	warned map[PieceColor]bool // whether the low time warning was already given
+}
+
+// lowTime is the time left under which a clock is shown with tenths of a second, and the player is warned
+var lowTime = 20 * time.Second
+
+// lowTimeDepth is the search depth the computer switches to when its own clock is low
+var lowTimeDepth = 2
+
+// LowTimeDepth returns the search depth SearchGame switches to when the clock of the side to move is low
+func LowTimeDepth() int {
+	return lowTimeDepth
+}
+
+func NewClock(initial, increment time.Duration) *Clock {
+	return NewOddsClock(initial, initial, increment)
+}
+
+// NewOddsClock starts each player with a different time, to give time odds
+func NewOddsClock(white, black, increment time.Duration) *Clock {
+	return &Clock{ map[PieceColor]time.Duration { PieceColor_White : white, PieceColor_Black : black },
+		map[PieceColor]time.Duration { PieceColor_White : white, PieceColor_Black : black }, increment,
+		map[PieceColor]bool {} }
+}
+
+func (c *Clock) Remaining(color PieceColor) time.Duration {
+	return c.remaining[color]
+}
+
+// Initial returns the time color started the game with
+func (c *Clock) Initial(color PieceColor) time.Duration {
+	return c.initial[color]
+}
+
+// TimeControl writes the time control as in the PGN TimeControl tag: seconds for each player, and the increment if
+// there is one, like "300+2"; "?" for time odds, which the tag can't express
+func (c *Clock) TimeControl() string {
+	if c.initial[PieceColor_White] != c.initial[PieceColor_Black] { return "?" }
+	control := fmt.Sprint(int64(c.initial[PieceColor_White].Seconds()))
+	if c.increment > 0 { control += fmt.Sprint("+", int64(c.increment.Seconds())) }
+	return control
+}
+
+// snapshot copies the clock as it is now, so it doesn't change when the game goes on; nil for no clock
+func (c *Clock) snapshot() *Clock {
+	if c == nil { return nil }
+	copied := NewOddsClock(c.initial[PieceColor_White], c.initial[PieceColor_Black], c.increment)
+	for color, remaining := range c.remaining {
+		copied.remaining[color] = remaining
+	}
+	return copied
+}
+
+// Spend takes the time used for a move from the clock of color, and tells whether it ran out of time
+func (c *Clock) Spend(color PieceColor, used time.Duration) (flagged bool) {
+	c.remaining[color] -= used
+	if c.remaining[color] <= 0 {
+		c.remaining[color] = 0
+		return true
+	}
+	c.remaining[color] += c.increment
+	return false
+}
+
+// MoveTime decides how much time color can spend on its next move: a slice of the remaining time, assuming the
+// game lasts about movesToGo more moves, plus most of the increment
+func (c *Clock) MoveTime(color PieceColor) time.Duration {
+	movesToGo := time.Duration(30)
+	moveTime := c.remaining[color] / movesToGo + c.increment * 3 / 4
+	if moveTime > c.remaining[color] { moveTime = c.remaining[color] }
+	return moveTime
+}
+
+// IsLow tells whether color is running out of time
+func (c *Clock) IsLow(color PieceColor) bool {
+	return c.remaining[color] < lowTime
+}
+
+// LowTimeWarning tells whether color just got low on time, only once per game
+func (c *Clock) LowTimeWarning(color PieceColor) bool {
+	if !c.IsLow(color) || c.warned[color] { return false }
+	c.warned[color] = true
+	return true
+}
+
+// FormatRemaining shows the time left for color in whole seconds, or tenths of a second when the time is low
+func (c *Clock) FormatRemaining(color PieceColor) string {
+	remaining := c.remaining[color]
+	if !c.IsLow(color) { return remaining.Round(time.Second).String() }
+	return fmt.Sprintf("%.1fs!", remaining.Seconds())
+}
+
+func (c *Clock) String() string {
+	return fmt.Sprint("White ", c.FormatRemaining(PieceColor_White), ", Black ", c.FormatRemaining(PieceColor_Black))
+}
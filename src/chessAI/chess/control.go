@@ -0,0 +1,57 @@
+package chess
+
+import "sort"
+
+// SquareControl returns the pieces fighting for a square, least valuable first. Attackers are the pieces of the side
+// that doesn't own the square: the opponents of the piece on it, or the side to move if it's empty. Defenders are the
+// pieces of the other side. Like StaticExchange, it doesn't take pins into account; PieceInfo's accessors and
+// String tell what each piece is.
+func SquareControl(position Position, square Square) (attackers, defenders []PieceInfo) {
+	attackingColor := position.sideToMove
+	if info := GetBoardAt(position.board, square); info.piece != Piece_Empty { attackingColor = !info.color }
+
+	attackers = controllingPieces(position.board, square, attackingColor)
+	defenders = controllingPieces(position.board, square, !attackingColor)
+	return
+}
+
+// controllingPieces returns the pieces of color attacking square, least valuable first
+func controllingPieces(board Board, square Square, color PieceColor) []PieceInfo {
+	pieces := []PieceInfo{}
+	for _, pos := range getAttackers(board, square, color) {
+		pieces = append(pieces, GetBoardAt(board, pos))
+	}
+	sort.SliceStable(pieces, func(i, j int) bool { return pieceScoreMap[pieces[i].piece] < pieceScoreMap[pieces[j].piece] })
+	return pieces
+}
+
+// NetControl scores who controls a square, positive if the attackers of SquareControl do. For a square with a piece
+// that can be captured it's the material the attackers win, or lose if negative, by starting an exchange on it with
+// their least valuable piece; otherwise it's how many more pieces the attackers have on it.
+func NetControl(position Position, square Square) int {
+	attackers, defenders := SquareControl(position, square)
+	if target := GetBoardAt(position.board, square); target.piece != Piece_Empty && target.piece != Piece_King && len(attackers) != 0 {
+		attackingSquares := getAttackers(position.board, square, !target.color)
+		return StaticExchange(position.board, leastValuable(position.board, attackingSquares), square)
+	}
+	return len(attackers) - len(defenders)
+}
+
+// leastValuable returns the square, out of a non empty list, with the least valuable piece
+func leastValuable(board Board, squares []Square) Square {
+	best := squares[0]
+	for _, pos := range squares[1:] {
+		if pieceScoreMap[GetBoardAt(board, pos).piece] < pieceScoreMap[GetBoardAt(board, best).piece] { best = pos }
+	}
+	return best
+}
+
+// DescribePieces writes pieces as FEN letters, like "PNq"
+func DescribePieces(pieces []PieceInfo) string {
+	letters := ""
+	for _, info := range pieces {
+		letters += info.String()
+	}
+	if letters == "" { return "-" }
+	return letters
+}
@@ -0,0 +1,14 @@
+// Package chess is the chess AI: positions and move generation, notation, evaluation and search. It doesn't read
+// or print anything; the chessAI program's interactive game and tools are in package chessAI/cli. Programs that
+// embed the engine start with ParseFEN or InitialPosition, and search with an Engine:
+//
+//	engine := chess.NewEngine()
+//	engine.SetDepth(4)
+//	position, _ := chess.ParseFEN("r1bqkbnr/pppp1ppp/2n5/4p3/4P3/5N2/PPPP1PPP/RNBQKB1R w KQkq - 2 3")
+//	move, score := engine.BestMove(position)
+//	fmt.Println(chess.ToSAN(position, move), score)
+//
+// Built with -tags rulesonly, the package only has the rules: positions and move generation, FEN, EPD, SAN and
+// PGN, and games with their results and clocks, without the evaluation and the search, nor the memory of their
+// transposition table.
+package chess
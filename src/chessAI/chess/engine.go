@@ -0,0 +1,133 @@
+//go:build !rulesonly
+
+package chess
+
+import "time"
+
+// Engine searches positions with a set of search options, for programs that embed the chess AI. It keeps its
+// transposition table from one search to the next, so searching the moves of a game one after the other reuses
+// what the earlier searches found; NewGame clears it.
+type Engine struct {
+	options SearchOptions
+}
+
+// NewEngine returns an engine with the default search options
+func NewEngine() *Engine {
+	return &Engine{ DefaultSearchOptions() }
+}
+
+// SetDepth sets the maximum search depth, in plies
+func (e *Engine) SetDepth(depth int) {
+	e.options.depth = depth
+}
+
+// SetMoveTime limits the time spent on each search, 0 means no limit
+func (e *Engine) SetMoveTime(moveTime time.Duration) {
+	e.options.moveTime = moveTime
+}
+
+// SetMoveOverhead sets the part of the move time kept aside for everything but the search
+func (e *Engine) SetMoveOverhead(overhead time.Duration) {
+	e.options.moveOverhead = overhead
+}
+
+// SetSwindle makes the engine prefer, when losing, moves the opponent is more likely to answer badly
+func (e *Engine) SetSwindle(swindle bool) {
+	e.options.swindle = swindle
+}
+
+// SetEasyMove makes the engine play right away, with a move time, when there's only one move or an obvious
+// recapture
+func (e *Engine) SetEasyMove(easyMove bool) {
+	e.options.easyMove = easyMove
+}
+
+// SetStartDepth makes the searches start at depth instead of 1, to resume an earlier search
+func (e *Engine) SetStartDepth(depth int) {
+	e.options.startDepth = depth
+}
+
+// SetMemoryMB sets the memory budget of the transposition table, which starts empty again with the new size
+func (e *Engine) SetMemoryMB(memoryMB int) {
+	e.options.maxMemoryMB = memoryMB
+	e.options.table = nil
+}
+
+// NewGame forgets what the earlier searches found, which is of no use in other games
+func (e *Engine) NewGame() {
+	if e.options.table != nil { e.options.table.Clear() }
+}
+
+// SetBot uses the search settings of one of the bots of BotNames, and tells whether there is such a bot
+func (e *Engine) SetBot(name string) bool {
+	bot, ok := bots[name]
+	if !ok { return false }
+	// the scores stored may come from another evaluation
+	bot.configure(&e.options)
+	e.NewGame()
+	return true
+}
+
+// SetObserver sets the observer told about the progress of every search, nil for none
+func (e *Engine) SetObserver(observer SearchObserver) {
+	e.options.observer = observer
+}
+
+// Options returns the search options the engine uses
+func (e *Engine) Options() SearchOptions {
+	return e.options
+}
+
+// Table returns the transposition table the searches share, nil before the first search
+func (e *Engine) Table() *TranspositionTable {
+	return e.options.table
+}
+
+// Fork returns a copy of the engine, whose settings can be changed without changing e's, that shares its
+// transposition table
+func (e *Engine) Fork() *Engine {
+	if e.options.table == nil { e.options.table = NewTranspositionTable(e.options.maxMemoryMB) }
+	fork := *e
+	return &fork
+}
+
+// Search searches position and returns everything the search found
+func (e *Engine) Search(position Position) SearchResult {
+	if e.options.table == nil { e.options.table = NewTranspositionTable(e.options.maxMemoryMB) }
+	return SearchBestMove(position, e.options)
+}
+
+// SearchGame searches the position a game is in. Unlike Search, it knows how the game got there: moves that
+// repeat earlier positions score as the draws they could lead to, and obvious recaptures are played quickly. With a
+// clock, the time of each search is decided by the time left.
+func (e *Engine) SearchGame(game *Game) SearchResult {
+	return SearchBestMove(game.position, e.gameOptions(game))
+}
+
+// gameOptions returns the options to search the position game is in
+func (e *Engine) gameOptions(game *Game) SearchOptions {
+	if e.options.table == nil { e.options.table = NewTranspositionTable(e.options.maxMemoryMB) }
+	options := e.options
+	options.history, options.previousBoard = game.repetitions, game.previousBoard
+
+	if clock := game.clock; clock != nil {
+		color := game.position.sideToMove
+		options.moveTime, options.opponentTime = clock.MoveTime(color), clock.Remaining(!color)
+		// short of time, a shallow search without swindles is safer than a deep one cut by the clock
+		if clock.IsLow(color) && options.depth > lowTimeDepth { options.depth, options.swindle = lowTimeDepth, false }
+	}
+	return options
+}
+
+// TakesDraw tells whether the engine would rather draw in position than play on, as it doesn't think it's better
+func (e *Engine) TakesDraw(position Position) bool {
+	return EvaluateBoard(position) <= drawScore
+}
+
+// BestMove searches position and returns the best move found, as the board after it, with its score from the side
+// to move's point of view. The move is the position's own board if there are no legal moves.
+func (e *Engine) BestMove(position Position) (move Board, score int) {
+	result := e.Search(position)
+	if result.bestMove == (Board{}) { return position.board, result.score }
+	return result.bestMove, result.score
+}
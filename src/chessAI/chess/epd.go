@@ -0,0 +1,123 @@
+package chess
+
+import "errors"
+import "fmt"
+import "strings"
+
+// EPDRecord is a position with operations, as in the EPD format used by engine test suites: opcodes like id (the
+// name of the test), bm (best moves) and am (moves to avoid), ce (evaluation in centipawns) and pv (principal
+// variation), with their operands
+type EPDRecord struct {
+	position Position
+	opcodes []string // in the order they were read or set
+	operands map[string][]string
+}
+
+// ParseEPD reads an EPD record: the first four fields of a FEN, followed by operations ended by ";"
+func ParseEPD(line string) (record EPDRecord, err error) {
+	fields := strings.Fields(line)
+	if len(fields) < 4 {
+		err = errors.New("EPD must have at least 4 fields")
+		return
+	}
+	if record.position, err = ParseFEN(strings.Join(fields[:4], " ")); err != nil { return }
+	record.operands = map[string][]string {}
+
+	// the rest of the line, after the fourth field
+	rest := line
+	for i := 0; i < 4; i ++ {
+		rest = strings.TrimSpace(rest)
+		rest = rest[strings.IndexAny(rest + " ", " \t"):]
+	}
+
+	var operation []string
+	var token strings.Builder
+	quoted := false
+	endToken := func() {
+		if token.Len() > 0 { operation = append(operation, token.String()) }
+		token.Reset()
+	}
+	for _, c := range rest {
+		switch {
+		case c == '"':
+			quoted = !quoted
+		case quoted:
+			token.WriteRune(c)
+		case c == ' ' || c == '\t':
+			endToken()
+		case c == ';':
+			endToken()
+			if len(operation) > 0 { record.Set(operation[0], operation[1:]...) }
+			operation = nil
+		default:
+			token.WriteRune(c)
+		}
+	}
+	endToken()
+	if quoted || len(operation) > 0 { err = fmt.Errorf("unfinished EPD operation %q", strings.Join(operation, " ")) }
+	return
+}
+
+// NewEPDRecord returns a record for position, without operations
+func NewEPDRecord(position Position) EPDRecord {
+	return EPDRecord{ position, nil, map[string][]string {} }
+}
+
+// Position returns the position of the record
+func (r EPDRecord) Position() Position {
+	return r.position
+}
+
+// Opcodes returns the opcodes of the record's operations, in the order they were read or set
+func (r EPDRecord) Opcodes() []string {
+	return r.opcodes
+}
+
+// Get returns the operands of opcode, and whether the record has it
+func (r EPDRecord) Get(opcode string) (operands []string, ok bool) {
+	operands, ok = r.operands[opcode]
+	return
+}
+
+// Set adds an operation, replacing the operands if the record already had opcode
+func (r *EPDRecord) Set(opcode string, operands ...string) {
+	if _, ok := r.operands[opcode]; !ok { r.opcodes = append(r.opcodes, opcode) }
+	r.operands[opcode] = operands
+}
+
+// Moves parses the operands of a move opcode like bm or am, written in SAN
+func (r EPDRecord) Moves(opcode string) (moves []Board, err error) {
+	for _, san := range r.operands[opcode] {
+		move, ok := ParseSAN(r.position, san)
+		if !ok { return nil, fmt.Errorf("%s %s isn't a legal move", opcode, san) }
+		moves = append(moves, move)
+	}
+	return
+}
+
+// String writes the record back in EPD; operands with spaces, like most ids, are quoted
+func (r EPDRecord) String() string {
+	fields := strings.Fields(ToFEN(r.position))
+	epd := strings.Join(fields[:4], " ")
+	for _, opcode := range r.opcodes {
+		epd += " " + opcode
+		for _, operand := range r.operands[opcode] {
+			if opcode == "id" || strings.ContainsAny(operand, " ;") { operand = `"` + operand + `"` }
+			epd += " " + operand
+		}
+		epd += ";"
+	}
+	return epd
+}
+
+// LineMoves finds the moves of a line in coordinate notation, played from position, stopping at the first one that
+// isn't legal
+func LineMoves(position Position, line []string) (moves []Board) {
+	for _, text := range line {
+		move, ok := ParseMove(position, text)
+		if !ok { break }
+		moves = append(moves, move)
+		position = position.Play(move)
+	}
+	return
+}
@@ -0,0 +1,10 @@
+package chess
+
+// GameObserver is told about what happens in the games it subscribes to with Game.Subscribe, so front ends can add
+// effects (sounds, notifications, logs) without working them out from the boards
+type GameObserver interface {
+	OnMove(position Position, move MoveInfo) // position is the one before the move
+	OnCapture(position Position, move MoveInfo) // after OnMove
+	OnCheck(position Position, move MoveInfo) // after OnMove and OnCapture, also for checkmates
+	OnGameEnd(result Result) // also when the game is adjourned
+}
@@ -0,0 +1,89 @@
+//go:build !rulesonly
+
+package chess_test
+
+import "fmt"
+import "strings"
+import "time"
+
+import "chessAI/chess"
+
+// Searching a position read from FEN
+func ExampleEngine() {
+	engine := chess.NewEngine()
+	engine.SetDepth(2)
+	position, err := chess.ParseFEN("6k1/5ppp/8/8/8/8/8/R5K1 w - - 0 1")
+	if err != nil { panic(err) }
+
+	move, score := engine.BestMove(position)
+	fmt.Println(chess.ToSAN(position, move), score)
+	// Output: Ra8# 1001
+}
+
+// printDepths is a SearchObserver that prints the best line found at each depth
+type printDepths struct{}
+
+func (printDepths) OnIterationComplete(info chess.SearchInfo) {
+	fmt.Println("depth", info.Depth(), "score", info.Score(), "pv", strings.Join(info.PV(), " "))
+}
+
+func (printDepths) OnBestMoveChange(info chess.SearchInfo) {}
+
+func (printDepths) OnFinish(result chess.SearchResult) {
+	fmt.Println("searched", result.Depth(), "plies")
+}
+
+// Following the progress of a search
+func ExampleEngine_SetObserver() {
+	engine := chess.NewEngine()
+	engine.SetDepth(2)
+	engine.SetObserver(printDepths{})
+	position, err := chess.ParseFEN("6k1/5ppp/8/8/8/8/8/R5K1 w - - 0 1")
+	if err != nil { panic(err) }
+
+	engine.Search(position)
+	// Output:
+	// depth 1 score 1000 pv a1a8
+	// depth 2 score 1001 pv a1a8
+	// searched 2 plies
+}
+
+// Making moves in SAN, and writing them back
+func ExampleParseSAN() {
+	position := chess.InitialPosition(false)
+	for _, san := range []string{ "e4", "e5", "Nf3", "Nc6", "Bb5" } {
+		move, ok := chess.ParseSAN(position, san)
+		if !ok { panic(san) }
+		position = position.Play(move)
+	}
+	fmt.Println(chess.ToFEN(position))
+	// Output: r1bqkbnr/pppp1ppp/2n5/1B2p3/4P3/5N2/PPPP1PPP/RNBQK2R b KQkq - 3 3
+}
+
+// Playing a game against the engine, and exporting it in PGN
+func ExampleGame() {
+	game := chess.NewGame(chess.InitialPosition(false), "Player", "chessAI")
+	engine := chess.NewEngine()
+	engine.SetDepth(2)
+
+	for _, san := range []string{ "f3", "g4" } {
+		if !game.PlaySAN(san) { panic(san) }
+		game.Play(engine.SearchGame(game).BestMove())
+	}
+	fmt.Println(game.Result())
+	fmt.Print(game.Result().PGN("Example", time.Date(2024, 1, 31, 0, 0, 0, 0, time.UTC)))
+	// Output:
+	// Black wins by checkmate
+	// [Event "Example"]
+	// [Site "?"]
+	// [Date "2024.01.31"]
+	// [Round "-"]
+	// [White "Player"]
+	// [Black "chessAI"]
+	// [Result "0-1"]
+	// [Termination "normal"]
+	// [PlyCount "4"]
+	// [TimeControl "-"]
+	//
+	// 1. f3 e6 2. g4 Qh4# 0-1
+}
@@ -0,0 +1,101 @@
+//go:build !rulesonly
+
+package chess
+
+import "bufio"
+import "fmt"
+import "io"
+import "os/exec"
+import "strconv"
+import "strings"
+
+// ExternalEngine talks UCI to another engine running as a separate process, to get second opinions on positions
+type ExternalEngine struct {
+	cmd *exec.Cmd
+	in io.WriteCloser
+	out *bufio.Scanner
+}
+
+// StartExternalEngine runs the engine at path and waits until it's ready to search
+func StartExternalEngine(path string) (engine *ExternalEngine, err error) {
+	cmd := exec.Command(path)
+	in, err := cmd.StdinPipe()
+	if err != nil { return }
+	out, err := cmd.StdoutPipe()
+	if err != nil { return }
+	if err = cmd.Start(); err != nil { return }
+
+	engine = &ExternalEngine{ cmd, in, bufio.NewScanner(out) }
+	for _, handshake := range [][2]string{ { "uci", "uciok" }, { "isready", "readyok" } } {
+		if err = engine.send(handshake[0]); err != nil { break }
+		if _, err = engine.waitFor(handshake[1]); err != nil { break }
+	}
+	if err != nil {
+		engine.Close()
+		return nil, fmt.Errorf("engine %s: %v", path, err)
+	}
+	return
+}
+
+func (e *ExternalEngine) send(command string) error {
+	_, err := fmt.Fprintln(e.in, command)
+	return err
+}
+
+// waitFor reads lines until one starts with prefix, and returns all the lines read before it
+func (e *ExternalEngine) waitFor(prefix string) (lines []string, err error) {
+	for e.out.Scan() {
+		line := strings.TrimSpace(e.out.Text())
+		if strings.HasPrefix(line, prefix) { return }
+		lines = append(lines, line)
+	}
+	err = e.out.Err()
+	if err == nil { err = io.ErrUnexpectedEOF }
+	return
+}
+
+// Evaluate searches position to the given depth, and returns the engine's last score for it, converted to the
+// units of EvaluateBoard: from the point of view of the side to move, with mates scored as checkMateScore
+func (e *ExternalEngine) Evaluate(position Position, depth int) (score int, err error) {
+	if err = e.send("position fen " + ToFEN(position)); err != nil { return }
+	if err = e.send(fmt.Sprint("go depth ", depth)); err != nil { return }
+
+	lines, err := e.waitFor("bestmove")
+	if err != nil { return }
+
+	found := false
+	for _, line := range lines {
+		if lineScore, ok := parseUCIScore(line); ok { score, found = lineScore, true }
+	}
+	if !found { err = fmt.Errorf("no score reported") }
+	return
+}
+
+// parseUCIScore reads the score in an info line, like "info depth 10 score cp 35 ..." or "info score mate -3", in
+// the units of EvaluateBoard, so it can be blended with ours
+func parseUCIScore(line string) (score int, ok bool) {
+	fields := strings.Fields(line)
+	if len(fields) == 0 || fields[0] != "info" { return }
+
+	for i := 0; i + 2 < len(fields); i ++ {
+		if fields[i] != "score" { continue }
+		value, err := strconv.Atoi(fields[i + 2])
+		if err != nil { return }
+
+		switch fields[i + 1] {
+		case "cp":
+			return fromCentipawns(value), true
+		case "mate":
+			if value < 0 { return - checkMateScore, true }
+			return checkMateScore, true
+		}
+	}
+	return
+}
+
+// Close asks the engine to quit, and waits for it
+func (e *ExternalEngine) Close() {
+	e.send("quit")
+	e.in.Close()
+	e.cmd.Wait()
+}
@@ -0,0 +1,207 @@
+package chess
+
+import "errors"
+import "fmt"
+import "strconv"
+import "strings"
+
+var fenPieceMap = map[byte]Piece {
+	'p' : Piece_Pawn, 'r' : Piece_Rock, 'n' : Piece_Knight, 'b' : Piece_Bishop, 'k' : Piece_King, 'q' : Piece_Queen,
+}
+
+var pieceLetterMap = map[Piece]string {
+	Piece_Pawn : "p", Piece_Rock : "r", Piece_Knight : "n", Piece_Bishop : "b", Piece_King : "k", Piece_Queen : "q",
+}
+
+// ParseFEN builds a position from a FEN string. Castling rights and the en-passant square are stored in the
+// status bits of the pieces involved.
+func ParseFEN(fen string) (position Position, err error) {
+	relaxed := false
+	return parseFEN(fen, relaxed)
+}
+
+// ParseStudyFEN is like ParseFEN, but tolerates the positions of composed studies and constructed puzzles, which
+// needn't be reachable in a game: castling rights and en-passant squares that don't match the pieces, and invalid
+// move clocks, are dropped instead of rejected. Any number of pieces is accepted, as in ParseFEN, but there must
+// still be one king of each color, and the side that just moved can't be in check.
+func ParseStudyFEN(fen string) (position Position, err error) {
+	relaxed := true
+	return parseFEN(fen, relaxed)
+}
+
+func parseFEN(fen string, relaxed bool) (position Position, err error) {
+	var board Board
+
+	fields := strings.Fields(fen)
+	if len(fields) < 4 {
+		err = errors.New("FEN must have at least 4 fields")
+		return
+	}
+
+	ranks := strings.Split(fields[0], "/")
+	if len(ranks) != 8 {
+		err = errors.New("FEN piece placement must have 8 ranks")
+		return
+	}
+
+	for y, rank := range ranks {
+		x := 0
+		for i := 0; i < len(rank); i ++ {
+			c := rank[i]
+			if c >= '1' && c <= '8' {
+				x += int(c - '0')
+				continue
+			}
+
+			piece, ok := fenPieceMap[strings.ToLower(string(c))[0]]
+			if !ok || x > 7 {
+				err = fmt.Errorf("invalid FEN rank %q", rank)
+				return
+			}
+
+			color := PieceColor(c >= 'A' && c <= 'Z')
+			status := PieceStatus_Default
+			if piece == Piece_Rock || piece == Piece_King { status = PieceStatus_CastlingNotAllowed }
+			SetBoardAt(&board, Square{x, y}, PieceInfo{ piece, status, color })
+			x ++
+		}
+		if x != 8 {
+			err = fmt.Errorf("invalid FEN rank %q", rank)
+			return
+		}
+	}
+
+	// everything else assumes there's always a king of each color
+	for _, color := range []PieceColor{ PieceColor_White, PieceColor_Black } {
+		if len(GetPieces(board, Piece_King, color)) != 1 {
+			err = fmt.Errorf("FEN must have exactly one %s king", strings.ToLower(color.String()))
+			return
+		}
+	}
+
+	switch fields[1] {
+	case "w":
+		position.sideToMove = PieceColor_White
+	case "b":
+		position.sideToMove = PieceColor_Black
+	default:
+		err = fmt.Errorf("invalid side to move %q", fields[1])
+		return
+	}
+
+	// even in a study, the king of the side that just moved can't be left in check
+	if givesCheck(board, position.sideToMove) {
+		err = fmt.Errorf("the %s king is in check, but it's not its turn", strings.ToLower((!position.sideToMove).String()))
+		return
+	}
+
+	if fields[2] != "-" {
+		for _, c := range fields[2] {
+			if !strings.ContainsRune("KQkq", c) {
+				err = fmt.Errorf("invalid castling rights %q", fields[2])
+				return
+			}
+
+			color := PieceColor(c == 'K' || c == 'Q')
+			row := 0
+			if color == PieceColor_White { row = 7 }
+
+			rockPos := Square{ 0, row }
+			if c == 'K' || c == 'k' { rockPos.x = 7 }
+
+			kingPos := Square{ 4, row }
+			rockInfo := GetBoardAt(board, rockPos)
+			kingInfo := GetBoardAt(board, kingPos)
+			if rockInfo.piece != Piece_Rock || rockInfo.color != color || kingInfo.piece != Piece_King || kingInfo.color != color {
+				if relaxed { continue }
+				err = fmt.Errorf("castling rights %q don't match the pieces", fields[2])
+				return
+			}
+
+			SetBoardAt(&board, rockPos, PieceInfo{ Piece_Rock, PieceStatus_Default, color })
+			SetBoardAt(&board, kingPos, PieceInfo{ Piece_King, PieceStatus_Default, color })
+		}
+	}
+
+	if fields[3] != "-" {
+		// the target square is behind a pawn of the side that just moved: on rank 6 when white moves, 3 when black does
+		target, squareErr := SquareFromString(fields[3])
+		targetRank := 6
+		if position.sideToMove == PieceColor_Black { targetRank = 3 }
+		validSquare := squareErr == nil && target.Rank() == targetRank
+		if !validSquare && !relaxed {
+			err = fmt.Errorf("invalid en-passant square %q", fields[3])
+			return
+		}
+
+		// the pawn that just moved two squares is right in front of the target square
+		var pawnPos Square
+		if validSquare {
+			pawnPos = SquareFromFileRank(target.File(), 5)
+			if target.Rank() == 3 { pawnPos = SquareFromFileRank(target.File(), 4) }
+		}
+
+		info := GetBoardAt(board, pawnPos)
+		if validSquare && info.piece == Piece_Pawn && info.color != position.sideToMove {
+			SetBoardAt(&board, pawnPos, PieceInfo{ Piece_Pawn, PieceStatus_EnPassantAllowed, info.color })
+		} else if !relaxed {
+			err = fmt.Errorf("no pawn can be captured en-passant at %q", fields[3])
+			return
+		}
+	}
+
+	// the clocks are optional
+	position.halfmoveClock, position.fullmoveNumber = 0, 1
+	if len(fields) >= 6 {
+		position.halfmoveClock, err = strconv.Atoi(fields[4])
+		if err == nil { position.fullmoveNumber, err = strconv.Atoi(fields[5]) }
+		if err != nil || position.halfmoveClock < 0 || position.fullmoveNumber < 1 {
+			if relaxed {
+				err = nil
+				position.halfmoveClock, position.fullmoveNumber = 0, 1
+			} else {
+				err = fmt.Errorf("invalid move clocks %q %q", fields[4], fields[5])
+				return
+			}
+		}
+	}
+
+	position.board = board
+	return
+}
+
+// ToFEN returns the FEN string for a position
+func ToFEN(position Position) string {
+	var fen strings.Builder
+
+	for y := 0; y < 8; y ++ {
+		empty := 0
+		for x := 0; x < 8; x ++ {
+			info := GetBoardAt(position.board, Square{x, y})
+			if info.piece == Piece_Empty {
+				empty ++
+				continue
+			}
+			if empty > 0 { fen.WriteString(strconv.Itoa(empty)) }
+			empty = 0
+
+			fen.WriteString(info.String())
+		}
+		if empty > 0 { fen.WriteString(strconv.Itoa(empty)) }
+		if y < 7 { fen.WriteString("/") }
+	}
+
+	if position.sideToMove == PieceColor_White {
+		fen.WriteString(" w ")
+	} else {
+		fen.WriteString(" b ")
+	}
+
+	fen.WriteString(position.CastlingRights().String())
+
+	enPassant := "-"
+	if square, ok := position.EnPassantSquare(); ok { enPassant = square.String() }
+
+	fmt.Fprintf(&fen, " %s %d %d", enPassant, position.halfmoveClock, position.fullmoveNumber)
+	return fen.String()
+}
@@ -0,0 +1,212 @@
+package chess
+
+import "strings"
+
+// moveHistory is how a game got to its current position
+type moveHistory struct {
+	start Position
+	moves []string // in coordinate notation
+}
+
+func (h *moveHistory) add(position Position, move Board) {
+	h.moves = append(h.moves, DescribeMove(position, move))
+}
+
+// Game is a game played move by move, by a program that embeds the engine or by the interactive game of the chessAI
+// program: it keeps the repetitions and clocks that decide draws, and gives the result and PGN of the game so far
+type Game struct {
+	position Position
+	plies int // half moves played, counting the ones played before a resumed game was adjourned
+	white, black string // player names
+	history moveHistory
+	repetitions map[positionKey]int // the positions reached since the last capture or pawn move, which can't be repeated after them
+	previousBoard Board // the board before the last move, zero if there was none
+	rules RulesProfile
+	clock *Clock // nil for games without one
+	computerTime *PhaseTimes // time used by the computer in each phase, nil if unknown
+	observers []GameObserver
+	legalMoves MoveCache // of position
+	end *Result // for the games ended by a draw claimed, a time forfeit or an adjournment, which the position doesn't tell
+}
+
+// NewGame starts a game from position, between players with the names given for the PGN tags, with the default
+// rules and no clock
+func NewGame(start Position, white, black string) *Game {
+	return ContinueGame(start, 0, white, black)
+}
+
+// ContinueGame is NewGame for games that already had plies half moves played before start, like adjourned games
+// that are resumed. The repetitions are only counted from start.
+func ContinueGame(start Position, plies int, white, black string) *Game {
+	return &Game{ position: start, plies: plies, white: white, black: black, history: moveHistory{ start: start },
+		repetitions: map[positionKey]int { start.key() : 1 }, rules: DefaultRules() }
+}
+
+// SetRules changes how draws are applied, see RulesProfile
+func (g *Game) SetRules(rules RulesProfile) {
+	g.rules = rules
+}
+
+// SetClock plays the game with a clock, which the players spend and Result shows; SearchGame decides how long to
+// search with it
+func (g *Game) SetClock(clock *Clock) {
+	g.clock = clock
+}
+
+// Clock returns the clock the game is played with, nil if none
+func (g *Game) Clock() *Clock {
+	return g.clock
+}
+
+// SetComputerTime sets the times the computer spent in each phase, kept by the caller, for the result
+func (g *Game) SetComputerTime(times *PhaseTimes) {
+	g.computerTime = times
+}
+
+// Subscribe makes observer follow the moves and the end of the game
+func (g *Game) Subscribe(observer GameObserver) {
+	g.observers = append(g.observers, observer)
+}
+
+// Position returns the position the game is in
+func (g *Game) Position() Position {
+	return g.position
+}
+
+// LegalMoves returns the legal moves of the position the game is in, generated once per position. The slice is
+// shared, and mustn't be modified.
+func (g *Game) LegalMoves() []MoveInfo {
+	return g.legalMoves.Moves(g.position)
+}
+
+// ParseMove finds the legal move written in SAN (Nf3, exd5, O-O, e8=Q) or coordinate notation (g1f3, e7e8q).
+// Promotions written without the piece, like e8 or e7e8, are promoted to promotion; see MissingPromotion.
+func (g *Game) ParseMove(text string, promotion Piece) (move Board, ok bool) {
+	if g.MissingPromotion(text) {
+		if promotion == Piece_Empty { return }
+		if _, ok = g.legalMoves.ParseSAN(g.position, text + "=Q"); ok {
+			return g.legalMoves.ParseSAN(g.position, text + "=" + strings.ToUpper(pieceLetterMap[promotion]))
+		}
+		text += pieceLetterMap[promotion]
+	}
+	if move, ok = g.legalMoves.ParseSAN(g.position, text); ok { return }
+	return g.legalMoves.ParseMove(g.position, text)
+}
+
+// MissingPromotion tells whether text is a promotion written without the piece, like e8 or e7e8
+func (g *Game) MissingPromotion(text string) bool {
+	if _, ok := g.legalMoves.ParseSAN(g.position, text); ok { return false }
+	if _, ok := g.legalMoves.ParseSAN(g.position, text + "=Q"); ok { return true }
+	return len(text) == 4 && isPromotionMove(g.position, text)
+}
+
+// Play plays a move, given as the board after it, and tells whether it was played: moves that aren't legal, and
+// moves after the game ended, aren't. The observers are told about the move, and about the end of the game if it
+// ends with it.
+func (g *Game) Play(move Board) bool {
+	if g.end != nil || g.Result().Finished() || !g.legalMoves.IsLegal(g.position, move) { return false }
+
+	before := g.position
+	g.history.add(g.position, move)
+	g.previousBoard = g.position.board
+	g.position = g.position.Play(move)
+	g.plies ++
+	if g.position.halfmoveClock == 0 { g.repetitions = map[positionKey]int {} }
+	g.repetitions[g.position.key()] ++
+
+	g.notifyMove(before, move)
+	if result := g.Result(); result.Finished() { g.notifyEnd(result) }
+	return true
+}
+
+// PlaySAN plays a move written in SAN, and tells whether it was played, as Play does
+func (g *Game) PlaySAN(san string) bool {
+	move, ok := g.legalMoves.ParseSAN(g.position, san)
+	return ok && g.Play(move)
+}
+
+// notifyMove tells the observers about the move from position to newBoard
+func (g *Game) notifyMove(position Position, newBoard Board) {
+	if len(g.observers) == 0 { return }
+
+	move := NewMoveInfo(position, newBoard)
+	for _, observer := range g.observers {
+		observer.OnMove(position, move)
+		if move.Is(MoveFlag_Capture) { observer.OnCapture(position, move) }
+		if move.Is(MoveFlag_Check) { observer.OnCheck(position, move) }
+	}
+}
+
+func (g *Game) notifyEnd(result Result) {
+	for _, observer := range g.observers {
+		observer.OnGameEnd(result)
+	}
+}
+
+// Result tells whether the game ended, and how: by checkmate, stalemate, insufficient material, the draws that the
+// rules apply without being claimed, or by the ends of ClaimDraw, TimeForfeit and Adjourn
+func (g *Game) Result() Result {
+	if g.end != nil { return *g.end }
+
+	result, ok := GetResult(g.position, g.plies)
+	if !ok {
+		if termination, draw := g.rules.automaticDraw(g.position, g.repetitions[g.position.key()]); draw {
+			result.termination, result.draw = termination, true
+		}
+	}
+	return g.describe(result)
+}
+
+// describe adds to result what the game knows about the players, and how it got to its position
+func (g *Game) describe(result Result) Result {
+	// a copy, so the moves played later aren't part of this result
+	history := g.history
+	result.white, result.black, result.computerTime, result.history = g.white, g.black, g.computerTime, &history
+	result.clock = g.clock.snapshot()
+	return result
+}
+
+// finish ends the game with result, telling the observers
+func (g *Game) finish(result Result) Result {
+	result = g.describe(result)
+	g.end = &result
+	g.notifyEnd(result)
+	return result
+}
+
+// ClaimableDraw tells whether the side to move can claim a draw, and for what
+func (g *Game) ClaimableDraw() (termination Termination, ok bool) {
+	if g.end != nil { return }
+	return g.rules.claimableDraw(g.position, g.repetitions[g.position.key()])
+}
+
+// ClaimDraw ends the game in a draw if the side to move can claim one, and tells whether it did
+func (g *Game) ClaimDraw() (result Result, ok bool) {
+	termination, ok := g.ClaimableDraw()
+	if !ok { return }
+	result, _ = GetResult(g.position, g.plies)
+	result.termination, result.draw = termination, true
+	return g.finish(result), true
+}
+
+// TimeForfeit ends the game lost by loser, who ran out of time
+func (g *Game) TimeForfeit(loser PieceColor) Result {
+	return g.finish(timeForfeitResult(g.position, loser, g.plies))
+}
+
+// Adjourn stops the game, to resume it later: its result is unfinished, with Termination_Adjourned
+func (g *Game) Adjourn() Result {
+	result, _ := GetResult(g.position, g.plies)
+	result.termination = Termination_Adjourned
+	return g.finish(result)
+}
+
+// RepetitionMoves returns the legal moves that end the game in a draw by repetition right away
+func (g *Game) RepetitionMoves() (moves []MoveInfo) {
+	for _, move := range g.LegalMoves() {
+		if g.repetitions[g.position.Play(move.board).key()] == g.rules.automaticRepetitions - 1 {
+			moves = append(moves, move)
+		}
+	}
+	return
+}
@@ -0,0 +1,79 @@
+package chess
+
+import "math/rand"
+import "strings"
+
+// Zobrist keys, generated from a fixed seed so a position has the same hash in every run and every build. Opening
+// books use PolyglotKey instead.
+var zobristPieces [2][Piece_Queen + 1][64]uint64 // by color (white first), piece and square index
+var zobristBlackToMove uint64
+var zobristCastling [4]uint64 // in FEN order: KQkq
+var zobristEnPassant [8]uint64 // by file
+
+const zobristSeed = 20240131
+
+func init() {
+	rnd := rand.New(rand.NewSource(zobristSeed))
+	for color := range zobristPieces {
+		for _, piece := range planePieces {
+			for i := range zobristPieces[color][piece] {
+				zobristPieces[color][piece][i] = rnd.Uint64()
+			}
+		}
+	}
+	zobristBlackToMove = rnd.Uint64()
+	for i := range zobristCastling {
+		zobristCastling[i] = rnd.Uint64()
+	}
+	for i := range zobristEnPassant {
+		zobristEnPassant[i] = rnd.Uint64()
+	}
+}
+
+// enPassantCapture returns the en-passant square if a pawn of the side to move is next to the pawn that can be
+// captured, as hashes and canonical FENs only count en-passant squares that make a difference. Like in Polyglot,
+// whether the capture is legal doesn't matter.
+func enPassantCapture(position Position) (square Square, ok bool) {
+	square, ok = position.EnPassantSquare()
+	if !ok { return }
+
+	// the capturing pawns are on the rank of the pawn that moved two squares
+	y := square.y + 1
+	if position.sideToMove == PieceColor_Black { y = square.y - 1 }
+	for _, x := range []int{ square.x - 1, square.x + 1 } {
+		pos := Square{ x, y }
+		if !SquareInBoard(pos) { continue }
+		info := GetBoardAt(position.board, pos)
+		if info.piece == Piece_Pawn && info.color == position.sideToMove { return square, true }
+	}
+	return square, false
+}
+
+// ZobristHash returns a 64 bit hash of the position, ignoring its clocks. Two positions have the same hash when
+// they have the same CanonicalFEN (barring collisions, which are very unlikely).
+func ZobristHash(position Position) (hash uint64) {
+	ForEachPiece(position.board, func(pos Square, info PieceInfo) {
+		color := 0
+		if info.color == PieceColor_Black { color = 1 }
+		hash ^= zobristPieces[color][info.piece][pos.x + pos.y * 8]
+	})
+	if position.sideToMove == PieceColor_Black { hash ^= zobristBlackToMove }
+
+	rights := position.CastlingRights()
+	for i, right := range []bool{ rights.whiteKingSide, rights.whiteQueenSide, rights.blackKingSide, rights.blackQueenSide } {
+		if right { hash ^= zobristCastling[i] }
+	}
+
+	if square, ok := enPassantCapture(position); ok { hash ^= zobristEnPassant[square.x] }
+	return
+}
+
+// CanonicalFEN writes the position as the first four fields of a FEN, without the clocks, and with the en-passant
+// square only when a pawn can capture there; equal positions always get the same string, whatever the move
+// that led to them
+func CanonicalFEN(position Position) string {
+	fields := strings.Fields(ToFEN(position))
+	fields[3] = "-"
+	if square, ok := enPassantCapture(position); ok { fields[3] = square.String() }
+	return strings.Join(fields[:4], " ")
+}
@@ -0,0 +1,123 @@
+package chess
+
+// Reason tells why IsLegal rejected a move
+type Reason uint8
+
+const (
+	Reason_None Reason = iota // the move is legal
+	Reason_OutOfBoard
+	Reason_NoPiece
+	Reason_WrongColor
+	Reason_OwnPieceCaptured
+	Reason_IllegalPieceMove
+	Reason_Blocked
+	Reason_CastlingNotAllowed
+	Reason_LeavesKingInCheck
+)
+
+var reasonNamesMap = map[Reason]string {
+	Reason_None : "legal move", Reason_OutOfBoard : "square outside of the board", Reason_NoPiece : "no piece to move",
+	Reason_WrongColor : "piece of the wrong color", Reason_OwnPieceCaptured : "can't capture own piece",
+	Reason_IllegalPieceMove : "the piece can't move like that", Reason_Blocked : "the way is blocked",
+	Reason_CastlingNotAllowed : "castling not allowed", Reason_LeavesKingInCheck : "the king would be in check",
+}
+
+func (r Reason) String() string {
+	return reasonNamesMap[r]
+}
+
+// IsLegal checks a single move for the side to move, without generating the rest of the moves available: the
+// geometry of the move is checked first, and then whether the resulting position leaves the king in check.
+// Promotions are legal for every piece type whenever the pawn move is, so the move doesn't say which one it is.
+func IsLegal(position Position, fullMove FullMove) (legal bool, reason Reason) {
+	board := position.board
+	color := position.sideToMove
+	from := fullMove.pos
+	to := SquareAdd(from, fullMove.move)
+
+	if !SquareInBoard(from) || !SquareInBoard(to) { return false, Reason_OutOfBoard }
+	info := GetBoardAt(board, from)
+	if info.piece == Piece_Empty { return false, Reason_NoPiece }
+	if info.color != color { return false, Reason_WrongColor }
+	if from == to { return false, Reason_IllegalPieceMove }
+	target := GetBoardAt(board, to)
+	if target.piece != Piece_Empty && target.color == color { return false, Reason_OwnPieceCaptured }
+
+	dx, dy := fullMove.move.x, fullMove.move.y
+	updateStates := true
+	newBoard := ApplyMove(board, fullMove, updateStates)
+
+	switch info.piece {
+	case Piece_Pawn:
+		newBoard, reason = pawnMoveBoard(board, fullMove, info)
+		if reason != Reason_None { return false, reason }
+
+	case Piece_Knight:
+		jump := false
+		for _, m := range knightJumps {
+			if m == fullMove.move { jump = true }
+		}
+		if !jump { return false, Reason_IllegalPieceMove }
+
+	case Piece_King:
+		if dy == 0 && (dx == 2 || dx == -2) {
+			// addCastlingMove also checks that the king doesn't go through attacked squares
+			if info.status != PieceStatus_Default { return false, Reason_CastlingNotAllowed }
+			if _, ok := addCastlingMove(board, from, info, sign0(dx)); !ok { return false, Reason_CastlingNotAllowed }
+			return true, Reason_None
+		}
+		if dx < -1 || dx > 1 || dy < -1 || dy > 1 { return false, Reason_IllegalPieceMove }
+
+	default:
+		between, aligned := squaresBetween(from, to)
+		diagonal := dx != 0 && dy != 0
+		if !aligned || (info.piece == Piece_Rock && diagonal) || (info.piece == Piece_Bishop && !diagonal) {
+			return false, Reason_IllegalPieceMove
+		}
+		for _, pos := range between {
+			if GetBoardAt(board, pos).piece != Piece_Empty { return false, Reason_Blocked }
+		}
+	}
+
+	kingPos := GetPieces(newBoard, Piece_King, color)[0]
+	if len(getAttackers(newBoard, kingPos, !color)) != 0 { return false, Reason_LeavesKingInCheck }
+	return true, Reason_None
+}
+
+// pawnMoveBoard checks the geometry of a pawn move, and returns the board after it (promoting to a queen)
+func pawnMoveBoard(board Board, fullMove FullMove, info PieceInfo) (newBoard Board, reason Reason) {
+	from := fullMove.pos
+	to := SquareAdd(from, fullMove.move)
+	dx, dy := fullMove.move.x, fullMove.move.y
+	updateStates := true
+
+	dir, startRank := 1, 1
+	if info.color == PieceColor_White { dir, startRank = -1, 6 }
+	target := GetBoardAt(board, to)
+
+	switch {
+	case dx == 0 && dy == dir:
+		if target.piece != Piece_Empty { return newBoard, Reason_Blocked }
+
+	case dx == 0 && dy == 2 * dir:
+		if from.y != startRank { return newBoard, Reason_IllegalPieceMove }
+		if target.piece != Piece_Empty || GetBoardAt(board, Square{ from.x, from.y + dir }).piece != Piece_Empty {
+			return newBoard, Reason_Blocked
+		}
+
+	case (dx == 1 || dx == -1) && dy == dir:
+		if target.piece != Piece_Empty { break }
+
+		enPassantInfo := GetBoardAt(board, Square{ to.x, from.y })
+		if enPassantInfo.piece != Piece_Pawn || enPassantInfo.color == info.color || enPassantInfo.status != PieceStatus_EnPassantAllowed {
+			return newBoard, Reason_IllegalPieceMove
+		}
+		return ApplyEnPassant(board, fullMove, updateStates), Reason_None
+
+	default:
+		return newBoard, Reason_IllegalPieceMove
+	}
+
+	if to.y == 0 || to.y == 7 { return ApplyPawnPromotion(board, fullMove, Piece_Queen, updateStates), Reason_None }
+	return ApplyMove(board, fullMove, updateStates), Reason_None
+}
@@ -0,0 +1,77 @@
+package chess
+
+import "math/bits"
+
+// MoveUndo is what UnmakeMove needs to take back a move played with MakeMove: the squares the move changed, with
+// what was on them before (this covers the captured piece, the rock moved by castling, the pawn taken en passant
+// and the statuses that changed), and the move clocks
+type MoveUndo struct {
+	squares [8]Square
+	infos [8]PieceInfo
+	count int
+	halfmoveClock, fullmoveNumber int
+}
+
+// set changes a square of board, remembering what was on it
+func (u *MoveUndo) set(board *Board, pos Square, info PieceInfo) {
+	u.squares[u.count], u.infos[u.count] = pos, GetBoardAt(*board, pos)
+	u.count ++
+	SetBoardAt(board, pos, info)
+}
+
+// MakeMove plays a generated move in place, with or without its board, leaving the position as Play would, and
+// returns what UnmakeMove needs to take it back. It's the alternative to copying the position for every move; the
+// search uses it, and perft -unmake compares both.
+func (p *Position) MakeMove(m MoveInfo) (undo MoveUndo) {
+	undo.halfmoveClock, undo.fullmoveNumber = p.halfmoveClock, p.fullmoveNumber
+	color := p.sideToMove
+
+	// en passant captures are only possible right after the double push, and that was the last move
+	enPassant := (pieceBits(p.board, Piece_Pawn, PieceColor_White) | pieceBits(p.board, Piece_Pawn, PieceColor_Black)) & p.board[PieceStatusBits]
+	for ; enPassant != 0; enPassant &= enPassant - 1 {
+		pos := SquareFromIndex(bits.TrailingZeros64(enPassant))
+		info := GetBoardAt(p.board, pos)
+		info.status = PieceStatus_Default
+		undo.set(&p.board, pos, info)
+	}
+
+	// status changes for castling and en passant, as in ApplyMove
+	moved := GetBoardAt(p.board, m.from)
+	switch {
+	case m.piece == Piece_King || m.piece == Piece_Rock:
+		moved.status = PieceStatus_CastlingNotAllowed
+	case m.piece == Piece_Pawn && (m.to.y - m.from.y == 2 || m.from.y - m.to.y == 2):
+		moved.status = PieceStatus_EnPassantAllowed
+	case m.piece == Piece_Pawn:
+		moved.status = PieceStatus_Default
+	}
+	if m.promotion != Piece_Empty {
+		moved.piece, moved.status = m.promotion, PieceStatus_Default
+		if m.promotion == Piece_Rock { moved.status = PieceStatus_CastlingNotAllowed }
+	}
+	undo.set(&p.board, m.from, EmptyPieceInfo)
+	undo.set(&p.board, m.to, moved)
+
+	if m.Is(MoveFlag_EnPassant) { undo.set(&p.board, Square{ m.to.x, m.from.y }, EmptyPieceInfo) }
+	if m.Is(MoveFlag_Castle) {
+		rockFrom, rockTo := Square{ 0, m.from.y }, Square{ 3, m.from.y }
+		if m.to.x > m.from.x { rockFrom, rockTo = Square{ 7, m.from.y }, Square{ 5, m.from.y } }
+		undo.set(&p.board, rockFrom, EmptyPieceInfo)
+		undo.set(&p.board, rockTo, PieceInfo{ Piece_Rock, PieceStatus_CastlingNotAllowed, color })
+	}
+
+	p.halfmoveClock ++
+	if m.captured != Piece_Empty || m.piece == Piece_Pawn { p.halfmoveClock = 0 }
+	if color == PieceColor_Black { p.fullmoveNumber ++ }
+	p.sideToMove = !color
+	return
+}
+
+// UnmakeMove takes back the move MakeMove played, given what it returned
+func (p *Position) UnmakeMove(undo MoveUndo) {
+	for i := undo.count - 1; i >= 0; i -- {
+		SetBoardAt(&p.board, undo.squares[i], undo.infos[i])
+	}
+	p.halfmoveClock, p.fullmoveNumber = undo.halfmoveClock, undo.fullmoveNumber
+	p.sideToMove = !p.sideToMove
+}
@@ -0,0 +1,80 @@
+package chess
+
+import "math/bits"
+import "strings"
+
+// pieceScoreMap has the value of each piece in pawns, for the evaluation and the exchanges
+var pieceScoreMap = map[Piece]int {
+	Piece_King : 1000, Piece_Queen : 9, Piece_Knight : 3, Piece_Bishop : 3, Piece_Rock : 5, Piece_Pawn : 1,
+}
+
+// MaterialSignature is a compact key with the number of pieces of each type on each side, and nothing else:
+// positions with the same material share the same signature, wherever the pieces are.
+// Each count takes 4 bits, white counts go in the low 32 bits and black counts in the high 32 bits.
+type MaterialSignature uint64
+
+const materialCountBits = 4
+
+// signaturePieces lists the pieces in the order used by MaterialSignature.String, strongest first
+var signaturePieces = []Piece{ Piece_King, Piece_Queen, Piece_Rock, Piece_Bishop, Piece_Knight, Piece_Pawn }
+
+func signatureShift(piece Piece, color PieceColor) uint {
+	shift := uint(piece - 1) * materialCountBits
+	if color == PieceColor_Black { shift += 32 }
+	return shift
+}
+
+// GetMaterialSignature computes the material signature of a board
+func GetMaterialSignature(board Board) (signature MaterialSignature) {
+	for _, color := range []PieceColor{ PieceColor_White, PieceColor_Black } {
+		for _, piece := range signaturePieces {
+			count := bits.OnesCount64(pieceBits(board, piece, color))
+			signature |= MaterialSignature(count) << signatureShift(piece, color)
+		}
+	}
+	return
+}
+
+// Count returns how many pieces of a given type and color there are
+func (m MaterialSignature) Count(piece Piece, color PieceColor) int {
+	return int(m >> signatureShift(piece, color)) & (1 << materialCountBits - 1)
+}
+
+// String describes the material like endgame tables do, white first: "KRPvKR"
+func (m MaterialSignature) String() string {
+	sides := []string{}
+	for _, color := range []PieceColor{ PieceColor_White, PieceColor_Black } {
+		side := ""
+		for _, piece := range signaturePieces {
+			side += strings.Repeat(strings.ToUpper(pieceLetterMap[piece]), m.Count(piece, color))
+		}
+		sides = append(sides, side)
+	}
+	return strings.Join(sides, "v")
+}
+
+// canForceMate tells whether color has enough material that checkmate could happen with the opponent's help
+func (m MaterialSignature) canForceMate(color PieceColor) bool {
+	if m.Count(Piece_Pawn, color) > 0 || m.Count(Piece_Rock, color) > 0 || m.Count(Piece_Queen, color) > 0 { return true }
+	return m.Count(Piece_Bishop, color) + m.Count(Piece_Knight, color) > 1
+}
+
+// InsufficientMaterial tells whether neither side can ever checkmate: king against king, or king and a single
+// minor piece against king, or kings and bishops only with every bishop on squares of the same color
+func InsufficientMaterial(board Board) bool {
+	m := GetMaterialSignature(board)
+	if m.canForceMate(PieceColor_White) || m.canForceMate(PieceColor_Black) { return false }
+
+	whiteMinors := m.Count(Piece_Bishop, PieceColor_White) + m.Count(Piece_Knight, PieceColor_White)
+	blackMinors := m.Count(Piece_Bishop, PieceColor_Black) + m.Count(Piece_Knight, PieceColor_Black)
+	if whiteMinors + blackMinors <= 1 { return true }
+	if m.Count(Piece_Knight, PieceColor_White) + m.Count(Piece_Knight, PieceColor_Black) > 0 { return false }
+
+	// light squares are those where x + y is even, as a1 is dark and a8 (0, 0) is light
+	bishops := append(GetPieces(board, Piece_Bishop, PieceColor_White), GetPieces(board, Piece_Bishop, PieceColor_Black)...)
+	light := (bishops[0].x + bishops[0].y) % 2
+	for _, bishop := range bishops[1:] {
+		if (bishop.x + bishop.y) % 2 != light { return false }
+	}
+	return true
+}
@@ -0,0 +1,6 @@
+//go:build !(js && wasm) && !rulesonly
+
+package chess
+
+// defaultMemoryMB is the memory budget of the search unless set otherwise
+const defaultMemoryMB = 64
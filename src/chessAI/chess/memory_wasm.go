@@ -0,0 +1,7 @@
+//go:build js && wasm && !rulesonly
+
+package chess
+
+// defaultMemoryMB is the low memory profile in browsers, where the search shares the JS heap with the page and a long
+// analysis could exhaust it
+const defaultMemoryMB = lowMemoryMB
@@ -0,0 +1,129 @@
+package chess
+
+import "strings"
+
+// Motif is a tactical pattern found in a line of play
+type Motif int
+
+const (
+	Motif_Fork Motif = iota
+	Motif_Pin
+	Motif_Skewer
+	Motif_DiscoveredAttack
+	Motif_BackRankMate
+)
+
+var motifNames = map[Motif]string {
+	Motif_Fork : "fork", Motif_Pin : "pin", Motif_Skewer : "skewer", Motif_DiscoveredAttack : "discovered attack",
+	Motif_BackRankMate : "back rank mate",
+}
+
+func (m Motif) String() string {
+	return motifNames[m]
+}
+
+// FindMotifs returns the motifs in the moves of line played by the side to move in position, each one once, in the
+// order they first appear. The motifs are found from the piece relations after each move, without searching: a
+// fork is a piece attacking two targets, a pin or skewer is a line piece attacking two enemy pieces one behind the
+// other, a discovered attack is a line piece attacking a new target after another piece moves out of its way. A
+// target is the king, a piece worth at least as much as the attacker, or an undefended one.
+func FindMotifs(position Position, line []Board) (motifs []Motif) {
+	found := map[Motif]bool {}
+	add := func(motif Motif) {
+		if !found[motif] { motifs = append(motifs, motif) }
+		found[motif] = true
+	}
+
+	color := position.sideToMove
+	for i, move := range line {
+		if i % 2 == 0 {
+			m := NewMoveInfo(position, move)
+			moved := GetBoardAt(move, m.to).piece
+
+			if len(attackedTargets(move, m.to, color)) >= 2 { add(Motif_Fork) }
+			if pin, skewer := lineAttacks(move, m.to, moved); pin || skewer {
+				if pin { add(Motif_Pin) }
+				if skewer { add(Motif_Skewer) }
+			}
+			// castling moves the rock, which isn't discovering anything
+			if !m.Is(MoveFlag_Castle) && discoveredAttack(position.board, move, m.to, color) { add(Motif_DiscoveredAttack) }
+			if m.Is(MoveFlag_Mate) && (moved == Piece_Rock || moved == Piece_Queen) && isBackRankMate(move, m.to, color) {
+				add(Motif_BackRankMate)
+			}
+		}
+		position = position.Play(move)
+	}
+	return
+}
+
+// isTarget tells whether the piece at square is worth attacking with attacker
+func isTarget(board Board, square Square, attacker Piece) bool {
+	info := GetBoardAt(board, square)
+	if info.piece == Piece_King || pieceScoreMap[info.piece] >= pieceScoreMap[attacker] { return true }
+	return len(getAttackers(board, square, info.color)) == 0
+}
+
+// attackedTargets returns the targets of the enemies of color attacked by the piece at from
+func attackedTargets(board Board, from Square, color PieceColor) (targets []Square) {
+	attacker := GetBoardAt(board, from).piece
+	for _, pos := range GetPiecesByColor(board, !color) {
+		if !isTarget(board, pos, attacker) { continue }
+		for _, a := range getAttackers(board, pos, color) {
+			if a == from { targets = append(targets, pos) }
+		}
+	}
+	return
+}
+
+// lineAttacks tells whether the line piece at from pins an enemy piece to a more valuable one behind it, or skewers
+// a more valuable one, or the king, to one behind it
+func lineAttacks(board Board, from Square, piece Piece) (pin, skewer bool) {
+	color := GetBoardAt(board, from).color
+	for i, dir := range rayDirections {
+		if piece != Piece_Queen && (piece != Piece_Rock || i >= 4) && (piece != Piece_Bishop || i < 4) { continue }
+
+		var pieces []PieceInfo
+		for pos := SquareAdd(from, dir); SquareInBoard(pos) && len(pieces) < 2; pos = SquareAdd(pos, dir) {
+			if info := GetBoardAt(board, pos); info.piece != Piece_Empty { pieces = append(pieces, info) }
+		}
+		if len(pieces) < 2 || pieces[0].color == color || pieces[1].color == color { continue }
+
+		front, back := pieceScoreMap[pieces[0].piece], pieceScoreMap[pieces[1].piece]
+		if back > front && back > pieceScoreMap[piece] { pin = true }
+		if front > back && front >= pieceScoreMap[piece] { skewer = true }
+	}
+	return
+}
+
+// discoveredAttack tells whether a move by color, from before to after, lets a piece other than the one that moved
+// (to) attack a new target
+func discoveredAttack(before, after Board, to Square, color PieceColor) bool {
+	for _, pos := range GetPiecesByColor(after, !color) {
+		attackedBefore := map[Square]bool {}
+		for _, a := range getAttackers(before, pos, color) {
+			attackedBefore[a] = true
+		}
+		for _, a := range getAttackers(after, pos, color) {
+			if a != to && !attackedBefore[a] && isTarget(after, pos, GetBoardAt(after, a).piece) { return true }
+		}
+	}
+	return false
+}
+
+// isBackRankMate tells whether the mate given by the piece at to, moved by color, is on the back rank of the enemy
+// king
+func isBackRankMate(board Board, to Square, color PieceColor) bool {
+	king := GetPieces(board, Piece_King, !color)[0]
+	backRank := 0
+	if !color == PieceColor_White { backRank = 7 }
+	return king.y == backRank && to.y == backRank
+}
+
+// DescribeMotifs writes motifs separated by commas
+func DescribeMotifs(motifs []Motif) string {
+	names := []string{}
+	for _, motif := range motifs {
+		names = append(names, motif.String())
+	}
+	return strings.Join(names, ", ")
+}
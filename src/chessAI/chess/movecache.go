@@ -0,0 +1,46 @@
+package chess
+
+// MoveCache keeps the legal moves of the last position it was asked about. Interactive programs need the moves of
+// the position on display again and again, to check and describe the moves typed in, and that position only changes
+// when a move is played, which gives it a different key. Each game and console needs its own cache, as it isn't safe
+// for concurrent use; the zero value is an empty cache.
+type MoveCache struct {
+	key positionKey
+	infos []MoveInfo // shared with the callers, which mustn't change them
+	moves []Board // the boards of infos
+	valid bool
+}
+
+// Moves returns GenerateMoves(position), only generating them if position isn't the cached one. The slice is
+// shared, and mustn't be modified.
+func (c *MoveCache) Moves(position Position) []MoveInfo {
+	if key := position.key(); !c.valid || key != c.key {
+		c.infos = GenerateMoves(position)
+		c.key, c.moves, c.valid = key, moveBoards(position.board, c.infos), true
+	}
+	return c.infos
+}
+
+// LegalMoves returns LegalMoves(position), only generating them if position isn't the cached one
+func (c *MoveCache) LegalMoves(position Position) []Board {
+	c.Moves(position)
+	return c.moves
+}
+
+// IsLegal tells whether newBoard is the result of one of the legal moves in position
+func (c *MoveCache) IsLegal(position Position, newBoard Board) bool {
+	for _, move := range c.LegalMoves(position) {
+		if move == newBoard { return true }
+	}
+	return false
+}
+
+// ParseMove is the package's ParseMove, choosing among the cached moves
+func (c *MoveCache) ParseMove(position Position, text string) (move Board, ok bool) {
+	return findMoveIn(position, text, c.Moves(position))
+}
+
+// ParseSAN is the package's ParseSAN, choosing among the cached moves
+func (c *MoveCache) ParseSAN(position Position, text string) (move Board, ok bool) {
+	return parseSAN(position, text, c.Moves(position))
+}
@@ -0,0 +1,197 @@
+package chess
+
+import "strings"
+
+// MoveFlags describes what a move does; each flag is a bit, so they can be combined
+type MoveFlags uint8
+
+const (
+	MoveFlag_Capture MoveFlags = 1 << iota
+	MoveFlag_Check
+	MoveFlag_Mate
+	MoveFlag_Promotion
+	MoveFlag_Castle
+	MoveFlag_EnPassant
+)
+
+// MoveInfo is a legal move with everything notation, move ordering and user interfaces need to know about it
+type MoveInfo struct {
+	board Board // the board after the move; the moves the search generates don't have it
+	from, to Square // castling is described by the king move
+	piece Piece // the piece moved, a pawn for promotions
+	captured Piece // Piece_Empty if the move isn't a capture
+	promotion Piece // Piece_Empty if the move isn't a promotion
+	flags MoveFlags
+}
+
+// moveKey tells a move apart from the other moves of its position, in much less memory than MoveInfo; it's what
+// the transposition table keeps of best moves
+type moveKey struct {
+	from, to uint8 // square indexes, x + 8 * y
+	promotion Piece
+}
+
+func (m MoveInfo) key() moveKey {
+	return moveKey{ uint8(m.from.x + 8 * m.from.y), uint8(m.to.x + 8 * m.to.y), m.promotion }
+}
+
+// findMoveKey returns the legal move of position with the given key, if there's one
+func findMoveKey(position Position, key moveKey) (move MoveInfo, ok bool) {
+	for _, m := range GenerateMoves(position) {
+		if m.key() == key { return m, true }
+	}
+	return
+}
+
+// NewMoveInfo finds out which move leads from position to newBoard, and what it does. The move generator describes
+// the moves as it builds them, see GenerateMoves; this is for boards that come from elsewhere, like user input.
+func NewMoveInfo(position Position, newBoard Board) MoveInfo {
+	return addCheckFlags(position, moveSquares(position, newBoard))
+}
+
+// addCheckFlags sets the check and mate flags of a move played in position
+func addCheckFlags(position Position, m MoveInfo) MoveInfo {
+	if givesCheck(m.board, position.sideToMove) {
+		m.flags |= MoveFlag_Check
+		if len(LegalMoves(position.Play(m.board))) == 0 { m.flags |= MoveFlag_Mate }
+	}
+	return m
+}
+
+// moveSquares is NewMoveInfo without the check and mate flags, which are the slow ones: it compares the boards
+// square by square
+func moveSquares(position Position, newBoard Board) (m MoveInfo) {
+	var fromInfo, toInfo PieceInfo
+	board := position.board
+	color := position.sideToMove
+	m.board = newBoard
+
+	for _, pos := range AllSquares() {
+		before := GetBoardAt(board, pos)
+		after := GetBoardAt(newBoard, pos)
+		if before.piece == after.piece && before.color == after.color { continue }
+
+		if before.piece != Piece_Empty && before.color == color && (fromInfo.piece != Piece_King) {
+			m.from, fromInfo = pos, before
+		}
+		if after.piece != Piece_Empty && after.color == color && (toInfo.piece != Piece_King) {
+			m.to, toInfo = pos, after
+		}
+	}
+	m.piece = fromInfo.piece
+
+	if captured := GetBoardAt(board, m.to); captured.piece != Piece_Empty && captured.color != color {
+		m.captured = captured.piece
+		m.flags |= MoveFlag_Capture
+	}
+	if m.piece == Piece_Pawn && m.from.x != m.to.x && m.captured == Piece_Empty {
+		m.captured = Piece_Pawn
+		m.flags |= MoveFlag_Capture | MoveFlag_EnPassant
+	}
+	if m.piece == Piece_Pawn && toInfo.piece != Piece_Pawn {
+		m.promotion = toInfo.piece
+		m.flags |= MoveFlag_Promotion
+	}
+	if m.piece == Piece_King && (m.to.x - m.from.x == 2 || m.from.x - m.to.x == 2) { m.flags |= MoveFlag_Castle }
+
+	return
+}
+
+// Board returns the board after the move, which is how Position.Play and the search take moves
+func (m MoveInfo) Board() Board {
+	return m.board
+}
+
+// From returns the square the piece moved from; the king's square for castling
+func (m MoveInfo) From() Square {
+	return m.from
+}
+
+// To returns the square the piece moved to; the king's square for castling
+func (m MoveInfo) To() Square {
+	return m.to
+}
+
+// Piece returns the piece moved, a pawn for promotions
+func (m MoveInfo) Piece() Piece {
+	return m.piece
+}
+
+// Captured returns the piece captured, Piece_Empty if the move isn't a capture
+func (m MoveInfo) Captured() Piece {
+	return m.captured
+}
+
+// Promotion returns the piece a pawn is promoted to, Piece_Empty if the move isn't a promotion
+func (m MoveInfo) Promotion() Piece {
+	return m.promotion
+}
+
+// Is tells whether the move has all the given flags
+func (m MoveInfo) Is(flags MoveFlags) bool {
+	return m.flags & flags == flags
+}
+
+// String writes the move in coordinate notation: "e2e4", or "e7e8q" for promotions
+func (m MoveInfo) String() string {
+	promotion := ""
+	if m.promotion != Piece_Empty { promotion = pieceLetterMap[m.promotion] }
+	return m.from.String() + m.to.String() + promotion
+}
+
+// ParseMove finds the legal move written in coordinate notation, allowing "x" and "-" between the squares; pawns
+// moved to the last rank without a promotion piece, like e7e8, are promoted to queens
+func ParseMove(position Position, text string) (move Board, ok bool) {
+	return findMoveIn(position, text, GenerateMoves(position))
+}
+
+// findMoveIn is ParseMove choosing among moves, the legal moves in position
+func findMoveIn(position Position, text string, moves []MoveInfo) (move Board, ok bool) {
+	text = strings.ToLower(strings.NewReplacer("x", "", "-", "").Replace(text))
+	if len(text) == 4 && isPromotionMove(position, text) { text += "q" }
+
+	for _, m := range moves {
+		if m.String() == text { return m.board, true }
+	}
+	return
+}
+
+// isPromotionMove tells whether a move in coordinate notation, without the promotion piece, moves a pawn to the
+// last rank
+func isPromotionMove(position Position, text string) bool {
+	from, err := SquareFromString(text[:2])
+	if err != nil { return false }
+	to, err := SquareFromString(text[2:4])
+	if err != nil { return false }
+	return GetBoardAt(position.board, from).piece == Piece_Pawn && (to.y == 0 || to.y == 7)
+}
+
+// GenerateMoves returns the legal moves in position with their squares, pieces, and capture, promotion, castling
+// and en passant flags, and the boards they lead to; LegalMoves is the same moves as boards. It leaves out the check
+// and mate flags, which take much longer to find; LegalMoveInfos includes them.
+func GenerateMoves(position Position) []MoveInfo {
+	return withBoards(position.board, searchMoves(position))
+}
+
+// searchMoves returns the legal moves in position as the move generator builds them, without the boards, which
+// the search doesn't need: it plays the moves with MakeMove
+func searchMoves(position Position) []MoveInfo {
+	filterCheckMoves := true
+	quickMode := false
+	return allMoves(position.board, position.sideToMove, filterCheckMoves, quickMode)
+}
+
+// searchCaptures returns the legal captures and promotions in position, as searchMoves does
+func searchCaptures(position Position) []MoveInfo {
+	filterCheckMoves := true
+	return allCaptures(position.board, position.sideToMove, filterCheckMoves)
+}
+
+// LegalMoveInfos returns the legal moves in position, with their flags
+func LegalMoveInfos(position Position) []MoveInfo {
+	infos := GenerateMoves(position)
+	for i := range infos {
+		infos[i] = addCheckFlags(position, infos[i])
+	}
+	return infos
+}
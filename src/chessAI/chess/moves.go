@@ -0,0 +1,487 @@
+package chess
+
+import "math"
+
+type Move struct {
+	x, y int // move relative to current position
+}
+
+type FullMove struct {
+	pos Square
+	move Move
+}
+
+// NewFullMove returns the move of the piece at from to the square to, to check it with IsLegal
+func NewFullMove(from, to Square) FullMove {
+	return FullMove{ from, Move{ to.x - from.x, to.y - from.y } }
+}
+
+// the result of applying a move
+type Turn struct {
+	board Board
+	lastMove FullMove
+}
+
+// MoveSeq contains a list of moves such that, moves[n] is valid only if moves[n - 1] is valid as well.
+// This allows us to tell easily that you can't, for example, move a rock two places away if you can't do it one time
+// away in the same direction.
+type MoveSeq []Move
+
+// MoveSeqs contains a list of MoveSeq; each MoveSeq is independent from the others
+type MoveSeqs []MoveSeq
+
+// movesMap stores the relative movement for each piece
+var movesMap = map[PieceColor]map[Piece]MoveSeqs {}
+
+// ApplyCastling applies the castling move in one specific direction; it assumes castling is valid
+func ApplyCastling(board Board, kingPos Square, kingInfo PieceInfo, direction int) (newBoard Board) {
+	var rockMove, kingMove FullMove
+	newBoard = board
+
+	if direction < 0 {
+		rockMove = FullMove{ Square{0, kingPos.y}, Move{3, 0} }
+	} else {
+		rockMove = FullMove{ Square{7, kingPos.y}, Move{-2, 0} }
+	}
+
+	SetBoardAt(&newBoard, rockMove.pos, PieceInfo{ Piece_Rock, PieceStatus_CastlingNotAllowed, kingInfo.color })
+	SetBoardAt(&newBoard, kingPos, PieceInfo{ kingInfo.piece, PieceStatus_CastlingNotAllowed, kingInfo.color })
+
+	updateStates := true
+	kingMove = FullMove{ kingPos, Move{direction * 2, 0} }
+	newBoard = ApplyMove(newBoard, rockMove, updateStates)
+	newBoard = ApplyMove(newBoard, kingMove, updateStates)
+
+	return
+}
+
+// ApplyEnPassant applies en-passant move; it assumes the move is valid
+func ApplyEnPassant(board Board, fullMove FullMove, updateStates bool) Board {
+	newPos := SquareAdd(fullMove.pos, fullMove.move)
+
+	board = ApplyMove(board, fullMove, updateStates)
+	SetBoardAt(&board, Square{ newPos.x, fullMove.pos.y }, EmptyPieceInfo)
+	return board
+}
+
+// ApplyPawnPromotion applies promotion move for one selected promotion type; it assumes the move is valid
+func ApplyPawnPromotion(board Board, fullMove FullMove, selectedPiece Piece, updateStates bool) Board {
+	info := GetBoardAt(board, fullMove.pos)
+	newPos := SquareAdd(fullMove.pos, fullMove.move)
+
+	board = ApplyMove(board, fullMove, updateStates)
+
+	status := PieceStatus_Default
+	if selectedPiece == Piece_Rock { status = PieceStatus_CastlingNotAllowed }
+	
+	SetBoardAt(&board, newPos, PieceInfo{ selectedPiece, status, info.color })
+
+	return board
+}
+
+// newMoveInfo describes a move of the piece at fullMove.pos in board, without building the board it leads to; the
+// callers add what the squares don't tell: en passant, promotion and castling
+func newMoveInfo(board Board, fullMove FullMove) MoveInfo {
+	to := SquareAdd(fullMove.pos, fullMove.move)
+	m := MoveInfo{ from: fullMove.pos, to: to, piece: GetBoardAt(board, fullMove.pos).piece }
+	if m.captured = GetBoardAt(board, to).piece; m.captured != Piece_Empty { m.flags |= MoveFlag_Capture }
+	return m
+}
+
+// moveBoard builds the board after a move played in board, with ApplyMove or the Apply function of its kind
+func moveBoard(board Board, m MoveInfo) Board {
+	updateStates := true
+	return applyMoveInfo(board, m, updateStates)
+}
+
+func applyMoveInfo(board Board, m MoveInfo, updateStates bool) Board {
+	fullMove := FullMove{ m.from, Move{ m.to.x - m.from.x, m.to.y - m.from.y } }
+	switch {
+	case m.Is(MoveFlag_Castle):
+		return ApplyCastling(board, m.from, GetBoardAt(board, m.from), sign0(fullMove.move.x))
+	case m.Is(MoveFlag_EnPassant):
+		return ApplyEnPassant(board, fullMove, updateStates)
+	case m.Is(MoveFlag_Promotion):
+		return ApplyPawnPromotion(board, fullMove, m.promotion, updateStates)
+	}
+	return ApplyMove(board, fullMove, updateStates)
+}
+
+// withBoards sets the boards the moves played in board lead to, for everything but the search, which takes moves
+// as they are generated
+func withBoards(board Board, moves []MoveInfo) []MoveInfo {
+	for i := range moves {
+		moves[i].board = moveBoard(board, moves[i])
+	}
+	return moves
+}
+
+// moveBoards returns the boards after the moves played in board, which is how most of the program takes moves
+func moveBoards(board Board, moves []MoveInfo) []Board {
+	updateStates := true
+	return applyMoveInfos(board, moves, updateStates)
+}
+
+func applyMoveInfos(board Board, moves []MoveInfo, updateStates bool) []Board {
+	boards := make([]Board, 0, len(moves))
+	for _, m := range moves {
+		boards = append(boards, applyMoveInfo(board, m, updateStates))
+	}
+	return boards
+}
+
+// addCastlingMove computes the move for a left or right castling move for the given king.
+// direction is either -1 (left) or 1 (right)
+func addCastlingMove(board Board, kingPos Square, kingInfo PieceInfo, direction int) (move MoveInfo, ok bool) {
+	var rockPos Square
+
+	rockPos = Square{ 0, kingPos.y }
+	if direction == 1 { rockPos.x = 7 }
+
+	rockInfo := GetBoardAt(board, rockPos)
+	if rockInfo.piece != Piece_Rock || rockInfo.status != PieceStatus_Default || rockInfo.color != kingInfo.color { return }
+
+	// all squares between king and rock must be empty
+	for xi := kingPos.x + direction; xi != rockPos.x; xi += direction {
+		newPos := Square{ xi, kingPos.y }
+		newInfo := GetBoardAt(board, newPos)
+
+		if newInfo.piece != Piece_Empty { return }
+	}
+
+	// neither the king square nor the two squares in the direction of the rock can be under attack
+	for xi := kingPos.x; xi != kingPos.x + 3 * direction; xi += direction {
+		newPos := Square{ xi, kingPos.y }
+		if isUnderAttack(board, newPos, kingInfo.color) { return }
+	}
+
+
+	ok = true
+	move = newMoveInfo(board, FullMove{ kingPos, Move{ direction * 2, 0 } })
+	move.flags |= MoveFlag_Castle
+	return
+}
+
+func addCastlingMoves(board Board, kingPos Square, kingInfo PieceInfo, moves []MoveInfo) (newMoves []MoveInfo) {
+
+	newMoves = moves
+	if kingInfo.status != PieceStatus_Default { return }
+
+	dirs := []int { -1, 1 }
+
+	for _, dir := range dirs {
+		move, ok := addCastlingMove(board, kingPos, kingInfo, dir)
+		if ok { newMoves = append(newMoves, move) }
+	}
+
+	return
+}
+
+// removeCheckMoves gets rid of any moves of color in board that put its king under attack; each move is made and
+// taken back in place, to look for attackers of the king
+func removeCheckMoves(board Board, moves []MoveInfo, color PieceColor) []MoveInfo {
+	newMoves := make([]MoveInfo, 0, len(moves))
+	position := Position{ board: board, sideToMove: color }
+	kingPos := GetPieces(board, Piece_King, color)[0]
+
+	for _, m := range moves {
+		undo := position.MakeMove(m)
+		pos := kingPos
+		if m.piece == Piece_King { pos = m.to }
+		if !isUnderAttack(position.board, pos, color) {
+			newMoves = append(newMoves, m)
+		}
+		position.UnmakeMove(undo)
+	}
+	
+	return newMoves
+}
+
+
+// GetPossibleMoves returns the list of moves that can be done by a single piece.
+// It doesn't take checks into account, except for castling.
+// Params:
+// - filterCheckMoves = true forces the removal of any moves that puts the king under attack.
+// - quickMode = true skips some steps that aren't necessary for secondary uses of this
+//   function: computing castling and updating state info.
+func GetPossibleMoves(board Board, pos Square, info PieceInfo, filterCheckMoves bool, quickMode bool) []Board {
+	return applyMoveInfos(board, pieceMoves(board, pos, info, filterCheckMoves, quickMode), !quickMode)
+}
+
+// pieceMoves is GetPossibleMoves describing each move, as the move generator builds them: without the boards
+func pieceMoves(board Board, pos Square, info PieceInfo, filterCheckMoves bool, quickMode bool) []MoveInfo {
+	if info.piece == Piece_Pawn {
+		capturesOnly := false
+		moves := getPawnMoves(board, pos, info, capturesOnly)
+		infos := addPawnMoveInfos(board, moves, make([]MoveInfo, 0, len(moves)))
+		if filterCheckMoves { infos = removeCheckMoves(board, infos, info.color) }
+		return infos
+	}
+
+	seqs := movesMap[info.color][info.piece]
+
+	moves := []Move{}
+
+	for _, seq := range seqs {
+		for _, move := range seq {
+			newPos := SquareAdd(pos, move)
+			if !SquareInBoard(newPos) { break }
+
+			infoHere := GetBoardAt(board, newPos)
+			if infoHere.piece == Piece_Empty {
+				moves = append(moves, move)
+			} else {
+				if infoHere.color != info.color { moves = append(moves, move) }
+				break
+			}
+		}
+	}
+
+
+	infos := make([]MoveInfo, 0, len(moves))
+	for _, m := range moves {
+		infos = append(infos, newMoveInfo(board, FullMove{ pos, m }))
+	}
+
+	if !quickMode && info.piece == Piece_King {
+		infos = addCastlingMoves(board, pos, info, infos)
+	}
+
+	if filterCheckMoves {
+		infos = removeCheckMoves(board, infos, info.color)
+	}
+
+	return infos
+}
+
+// GetAllPossibleMoves returns all possible moves for pieces of a given color
+// (more details about arguments in GetPossibleMoves)
+func GetAllPossibleMoves(board Board, color PieceColor, filterCheckMoves bool, quickMode bool) []Board {
+	return applyMoveInfos(board, allMoves(board, color, filterCheckMoves, quickMode), !quickMode)
+}
+
+// allMoves is GetAllPossibleMoves describing each move
+func allMoves(board Board, color PieceColor, filterCheckMoves bool, quickMode bool) []MoveInfo {
+	if filterCheckMoves {
+		kingPos := GetPieces(board, Piece_King, color)[0]
+		checkers := getAttackers(board, kingPos, !color)
+		if len(checkers) != 0 { return getEvasions(board, color, kingPos, checkers, quickMode) }
+	}
+
+	positions := GetPiecesByColor(board, color)
+	moves := []MoveInfo{}
+
+	for _, pos := range positions {
+		info := GetBoardAt(board, pos)
+		moves = append(moves, pieceMoves(board, pos, info, filterCheckMoves, quickMode)...)
+	}
+	
+	return moves
+}
+
+// GetPossibleCaptures returns the captures (including en-passant) and promotions that can be done by a single
+// piece, without generating its quiet moves. Castling is never included.
+func GetPossibleCaptures(board Board, pos Square, info PieceInfo, filterCheckMoves bool) []Board {
+	return moveBoards(board, pieceCaptures(board, pos, info, filterCheckMoves))
+}
+
+// pieceCaptures is GetPossibleCaptures describing each move
+func pieceCaptures(board Board, pos Square, info PieceInfo, filterCheckMoves bool) []MoveInfo {
+	infos := []MoveInfo{}
+
+	if info.piece == Piece_Pawn {
+		capturesOnly := true
+		infos = addPawnMoveInfos(board, getPawnMoves(board, pos, info, capturesOnly), infos)
+	} else {
+		for _, seq := range movesMap[info.color][info.piece] {
+			for _, move := range seq {
+				newPos := SquareAdd(pos, move)
+				if !SquareInBoard(newPos) { break }
+
+				infoHere := GetBoardAt(board, newPos)
+				if infoHere.piece == Piece_Empty { continue }
+				if infoHere.color != info.color { infos = append(infos, newMoveInfo(board, FullMove{ pos, move })) }
+				break
+			}
+		}
+	}
+
+	if filterCheckMoves {
+		infos = removeCheckMoves(board, infos, info.color)
+	}
+
+	return infos
+}
+
+// GetAllCaptureMoves returns all the captures and promotions for pieces of a given color; this is what quiescence
+// search needs, and it's much cheaper than generating all moves and filtering them
+func GetAllCaptureMoves(board Board, color PieceColor, filterCheckMoves bool) []Board {
+	return moveBoards(board, allCaptures(board, color, filterCheckMoves))
+}
+
+// allCaptures is GetAllCaptureMoves describing each move
+func allCaptures(board Board, color PieceColor, filterCheckMoves bool) []MoveInfo {
+	moves := []MoveInfo{}
+
+	for _, pos := range GetPiecesByColor(board, color) {
+		info := GetBoardAt(board, pos)
+		moves = append(moves, pieceCaptures(board, pos, info, filterCheckMoves)...)
+	}
+
+	return moves
+}
+
+// isUnderAttack tells whether a piece with color=color at pos, or an empty square a piece of color would go
+// through, is under attack by any enemy piece
+func isUnderAttack(board Board, pos Square, color PieceColor) bool {
+	return len(getAttackers(board, pos, !color)) != 0
+}
+
+// IsValidMove tells whether moving the piece at piecePos can lead to newBoard
+func IsValidMove(position Position, piecePos Square, newBoard Board) bool {
+
+	quickMode := false
+	filterCheckMoves := true
+	info := GetBoardAt(position.board, piecePos)
+	if info.piece == Piece_Empty || info.color != position.sideToMove { return false }
+
+	moves := GetPossibleMoves(position.board, piecePos, info, filterCheckMoves, quickMode)
+
+	for _, m := range moves {
+		if m == newBoard { return true }
+	}
+
+	return false
+}
+
+// DescribeMove tells which move leads from board to newBoard, in coordinate notation: "e2e4", or "e7e8q" for
+// promotions. Castling is described by the king move.
+func DescribeMove(position Position, newBoard Board) string {
+	return moveSquares(position, newBoard).String()
+}
+
+func GetPossibleMoveCount(board Board, color PieceColor, filterCheckMoves bool) int {
+	quickMode := true
+	return len(GetAllPossibleMoves(board, color, filterCheckMoves, quickMode))
+}
+
+// resetPawnsStatus resets the status of all pawns of a given color; this means no contrary pawn can capture
+// any pawn using en-passant after this
+func resetPawnsStatus(board Board, color PieceColor) Board {
+	for _, pos := range GetPieces(board, Piece_Pawn, color) {
+		info := GetBoardAt(board, pos)
+		SetBoardAt(&board, pos, PieceInfo{ info.piece, PieceStatus_Default, info.color })
+	}
+
+	return board
+}
+
+// ApplyMove executes a move in a board; it assumes the move is a valid one, and it only applies simple moves
+// (castling or en-passant can't use this function)
+// Because the resetPawnsStatus call that updates states for the en-passant capture can be slow, we allow that
+// to be disabled.
+func ApplyMove(board Board, fullMove FullMove, updateStates bool) Board {
+	info := GetBoardAt(board, fullMove.pos)
+
+	// switch state changes for castling & en-passant
+	if updateStates {
+		board = resetPawnsStatus(board, info.color)
+		if info.piece == Piece_King || info.piece == Piece_Rock {
+			info.status = PieceStatus_CastlingNotAllowed
+		}
+		if info.piece == Piece_Pawn && math.Abs(float64(fullMove.move.y)) == 2. {
+			info.status = PieceStatus_EnPassantAllowed
+		}
+		if info.piece == Piece_Pawn && math.Abs(float64(fullMove.move.y)) == 1. {
+			info.status = PieceStatus_Default
+		}
+	}
+
+	SetBoardAt(&board, fullMove.pos, EmptyPieceInfo)
+	SetBoardAt(&board, SquareAdd(fullMove.pos, fullMove.move), info)
+	return board
+}
+
+func initMovesMap(color PieceColor) map[Piece]MoveSeqs {
+	m := make(map[Piece]MoveSeqs)
+
+	// each sequence has to be in an order such that move n can only be done if
+	// move n-1 is also possible (this takes care of collisions)
+
+	// pawns have their own move generation, see getPawnMoves
+
+	// rock
+	rmoves := MoveSeqs{ MoveSeq{}, MoveSeq{}, MoveSeq{}, MoveSeq{} }
+	for i := 1; i < 8; i ++ {
+		rmoves[0] = append(rmoves[0], Move{0, i})
+		rmoves[1] = append(rmoves[1], Move{0, -i})
+		rmoves[2] = append(rmoves[2], Move{i, 0})
+		rmoves[3] = append(rmoves[3], Move{-i, 0})
+	}
+	m[Piece_Rock] = MoveSeqs{ MoveSeq(rmoves[0]), MoveSeq(rmoves[1]), MoveSeq(rmoves[2]), MoveSeq(rmoves[3]) }
+
+	// bishop
+	bmoves := MoveSeqs{ MoveSeq{}, MoveSeq{}, MoveSeq{}, MoveSeq{} }
+	for i := 1; i < 8; i ++ {
+		bmoves[0] = append(bmoves[0], Move{i, i})
+		bmoves[1] = append(bmoves[1], Move{-i, -i})
+		bmoves[2] = append(bmoves[2], Move{i, -i})
+		bmoves[3] = append(bmoves[3], Move{-i, i})
+	}
+	m[Piece_Bishop] = MoveSeqs{ MoveSeq(bmoves[0]), MoveSeq(bmoves[1]), MoveSeq(bmoves[2]), MoveSeq(bmoves[3]) }
+
+	// queen
+	qmoves := MoveSeqs{}
+	qmoves = append(qmoves, rmoves...)
+	qmoves = append(qmoves, bmoves...)
+	m[Piece_Queen] = qmoves
+
+	// king
+	kmoves := MoveSeqs{}
+	for i := 0; i < len(qmoves); i ++ {
+		kmoves = append(kmoves, MoveSeq{ qmoves[i][0] })
+	}
+	m[Piece_King] = kmoves
+
+	// knight
+	m[Piece_Knight] = MoveSeqs{
+		MoveSeq{ Move{-2, -1} }, MoveSeq{ Move{-1, -2} },
+		MoveSeq{ Move{2, 1} }, MoveSeq{ Move{1, 2} },
+		MoveSeq{ Move{-2, 1} }, MoveSeq{ Move{-1, 2} },
+		MoveSeq{ Move{2, -1} }, MoveSeq{ Move{1, -2} },
+	 }
+
+	return m
+}
+
+// isCheckMate tells whether the king of a color is in checkmate.
+func isCheckMate(board Board, availableMoveCount int, color PieceColor) bool {
+	kingPos := GetPieces(board, Piece_King, color)[0]
+	if availableMoveCount == 0 && isUnderAttack(board, kingPos, color) { return true }
+	return false
+}
+
+// GetGameStatus tells whether game is finished or not, and who wins if it is finished
+// (availableMoveCount is the number of moves available to the side to move)
+func GetGameStatus(position Position, availableMoveCount int) (finished bool, draw bool, winningColor PieceColor) {
+	finished = true
+
+	if isCheckMate(position.board, availableMoveCount, position.sideToMove) {
+		winningColor = !position.sideToMove
+		return
+	}
+
+	if availableMoveCount == 0 || InsufficientMaterial(position.board) {
+		draw = true
+		return
+	}
+	
+	finished = false
+	return
+}
+
+func init() {
+	movesMap[PieceColor_Black] = initMovesMap(PieceColor_Black)
+	movesMap[PieceColor_White] = initMovesMap(PieceColor_White)
+}
+
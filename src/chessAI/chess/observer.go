@@ -0,0 +1,11 @@
+//go:build !rulesonly
+
+package chess
+
+// SearchObserver follows the progress of SearchBestMove, so the command line, a GUI or a check can all show or use
+// it in their own way
+type SearchObserver interface {
+	OnIterationComplete(info SearchInfo) // after each depth completed
+	OnBestMoveChange(info SearchInfo) // after a depth completed with a new best move, including the first depth
+	OnFinish(result SearchResult) // once, with what SearchBestMove returns
+}
@@ -0,0 +1,96 @@
+package chess
+
+// PawnMoveKind tells the different ways a pawn can move; each kind is generated in a single place, so the same
+// move can't be produced twice
+type PawnMoveKind uint8
+
+const (
+	PawnMoveKind_Push PawnMoveKind = iota
+	PawnMoveKind_DoublePush
+	PawnMoveKind_Capture
+	PawnMoveKind_EnPassant
+)
+
+var pawnMoveKindNamesMap = map[PawnMoveKind]string {
+	PawnMoveKind_Push : "push", PawnMoveKind_DoublePush : "double push", PawnMoveKind_Capture : "capture",
+	PawnMoveKind_EnPassant : "en-passant",
+}
+
+func (k PawnMoveKind) String() string {
+	return pawnMoveKindNamesMap[k]
+}
+
+// pawnMove is a pawn move, before choosing the piece to promote to
+type pawnMove struct {
+	move FullMove
+	kind PawnMoveKind
+}
+
+var promotionPieces = []Piece{ Piece_Queen, Piece_Rock, Piece_Bishop, Piece_Knight }
+
+// isPromotion tells whether a pawn move reaches the last rank
+func (m pawnMove) isPromotion() bool {
+	newPos := SquareAdd(m.move.pos, m.move.move)
+	return newPos.y == 0 || newPos.y == 7
+}
+
+// getPawnMoves lists the moves of the pawn at pos, without checking whether they leave the king in check.
+// If capturesOnly is set, quiet moves are left out except for promotions.
+func getPawnMoves(board Board, pos Square, info PieceInfo, capturesOnly bool) (moves []pawnMove) {
+	yDirection, startRank := 1, 1
+	if info.color == PieceColor_White { yDirection, startRank = -1, 6 }
+	newy := pos.y + yDirection
+	if newy < 0 || newy > 7 { return }
+
+	push := pawnMove{ FullMove{ pos, Move{ 0, yDirection } }, PawnMoveKind_Push }
+	if GetBoardAt(board, Square{ pos.x, newy }).piece == Piece_Empty {
+		if !capturesOnly || push.isPromotion() { moves = append(moves, push) }
+
+		doublePushPos := Square{ pos.x, newy + yDirection }
+		if !capturesOnly && pos.y == startRank && GetBoardAt(board, doublePushPos).piece == Piece_Empty {
+			moves = append(moves, pawnMove{ FullMove{ pos, Move{ 0, 2 * yDirection } }, PawnMoveKind_DoublePush })
+		}
+	}
+
+	for _, xDirection := range []int{ -1, 1 } {
+		newx := pos.x + xDirection
+		if newx < 0 || newx > 7 { continue }
+		fullMove := FullMove{ pos, Move{ xDirection, yDirection } }
+
+		enemyInfo := GetBoardAt(board, Square{ newx, newy })
+		if enemyInfo.piece != Piece_Empty {
+			if enemyInfo.color != info.color { moves = append(moves, pawnMove{ fullMove, PawnMoveKind_Capture }) }
+			continue
+		}
+
+		enPassantInfo := GetBoardAt(board, Square{ newx, pos.y })
+		if enPassantInfo.color != info.color && enPassantInfo.piece == Piece_Pawn && enPassantInfo.status == PieceStatus_EnPassantAllowed {
+			moves = append(moves, pawnMove{ fullMove, PawnMoveKind_EnPassant })
+		}
+	}
+
+	return
+}
+
+// addPawnMoveInfos adds the pawn moves to infos, one per piece for promotions
+func addPawnMoveInfos(board Board, moves []pawnMove, infos []MoveInfo) []MoveInfo {
+	for _, m := range moves {
+		info := newMoveInfo(board, m.move)
+		switch {
+		case m.kind == PawnMoveKind_EnPassant:
+			info.captured = Piece_Pawn
+			info.flags |= MoveFlag_Capture | MoveFlag_EnPassant
+			infos = append(infos, info)
+		case m.isPromotion():
+			info.flags |= MoveFlag_Promotion
+			for _, piece := range promotionPieces {
+				info.promotion = piece
+				infos = append(infos, info)
+			}
+		default:
+			infos = append(infos, info)
+		}
+	}
+
+	return infos
+}
@@ -0,0 +1,42 @@
+package chess
+
+// Perft counts the positions reached after playing every sequence of depth legal moves, to check the move generator
+// against known counts (119060324 at depth 6 from the initial position)
+func Perft(position Position, depth int) int {
+	if depth == 0 { return 1 }
+
+	moves := GenerateMoves(position)
+	if depth == 1 { return len(moves) }
+
+	count := 0
+	for _, move := range moves {
+		count += Perft(position.Play(move.board), depth - 1)
+	}
+	return count
+}
+
+// PerftUnmake is Perft walking the tree with MakeMove and UnmakeMove on a single position, instead of copying it
+// for every move; position is left as it was
+func PerftUnmake(position *Position, depth int) int {
+	if depth == 0 { return 1 }
+
+	moves := searchMoves(*position)
+	if depth == 1 { return len(moves) }
+
+	count := 0
+	for _, move := range moves {
+		undo := position.MakeMove(move)
+		count += PerftUnmake(position, depth - 1)
+		position.UnmakeMove(undo)
+	}
+	return count
+}
+
+// Divide is Perft split by the first move, in coordinate notation, to find where move generation goes wrong
+func Divide(position Position, depth int) map[string]int {
+	counts := map[string]int {}
+	for _, move := range LegalMoves(position) {
+		counts[DescribeMove(position, move)] = Perft(position.Play(move), depth - 1)
+	}
+	return counts
+}
@@ -0,0 +1,57 @@
+package chess
+
+import "fmt"
+import "strings"
+import "time"
+
+// pgnLineLength is the longest line of move text written in PGN
+const pgnLineLength = 79
+
+// PGN writes the game that ended with the result in PGN: the seven tag roster, the rest of PGNTags, and the moves
+// in SAN. Games that didn't start from the initial position get SetUp and FEN tags. It needs the result's history,
+// and only has the tags without it.
+func (r Result) PGN(event string, date time.Time) string {
+	tags := [][2]string{ { "Event", event }, { "Site", "?" }, { "Date", date.Format("2006.01.02") }, { "Round", "-" } }
+	tags = append(tags, r.PGNTags()...)
+
+	var start Position
+	var moves []Board
+	if r.history != nil {
+		start = r.history.start
+		moves = LineMoves(start, r.history.moves)
+		if fen := ToFEN(start); fen != namedPositions["start"].fen {
+			tags = append(tags, [2]string{ "SetUp", "1" }, [2]string{ "FEN", fen })
+		}
+	}
+
+	var pgn strings.Builder
+	for _, tag := range tags {
+		fmt.Fprintf(&pgn, "[%s %q]\n", tag[0], tag[1])
+	}
+	pgn.WriteString("\n")
+
+	var tokens []string
+	position := start
+	for i, move := range moves {
+		if position.sideToMove == PieceColor_White {
+			tokens = append(tokens, fmt.Sprint(position.fullmoveNumber, "."))
+		} else if i == 0 {
+			tokens = append(tokens, fmt.Sprint(position.fullmoveNumber, "..."))
+		}
+		tokens = append(tokens, ToSAN(position, move))
+		position = position.Play(move)
+	}
+	tokens = append(tokens, r.Score())
+
+	line := ""
+	for _, token := range tokens {
+		if line != "" && len(line) + 1 + len(token) > pgnLineLength {
+			pgn.WriteString(line + "\n")
+			line = ""
+		}
+		if line != "" { line += " " }
+		line += token
+	}
+	pgn.WriteString(line + "\n")
+	return pgn.String()
+}
@@ -0,0 +1,87 @@
+package chess
+
+import "encoding/json"
+import "fmt"
+import "time"
+
+type GamePhase uint8
+
+const (
+	GamePhase_Opening GamePhase = iota
+	GamePhase_Middlegame
+	GamePhase_Endgame
+)
+
+var gamePhases = []GamePhase{ GamePhase_Opening, GamePhase_Middlegame, GamePhase_Endgame }
+
+var gamePhaseNamesMap = map[GamePhase]string {
+	GamePhase_Opening : "opening", GamePhase_Middlegame : "middlegame", GamePhase_Endgame : "endgame",
+}
+
+func (p GamePhase) String() string {
+	return gamePhaseNamesMap[p]
+}
+
+// openingMoves is how many moves the opening is considered to last
+var openingMoves = 10
+
+// endgameMaterial is the most material, besides kings and pawns and adding both sides, left in an endgame: about a
+// rock and a minor piece each
+var endgameMaterial = 16
+
+// GetGamePhase tells the phase of the game from the material left and the move number
+func GetGamePhase(position Position) GamePhase {
+	material := 0
+	ForEachPiece(position.board, func(pos Square, info PieceInfo) {
+		if info.piece != Piece_King && info.piece != Piece_Pawn { material += pieceScoreMap[info.piece] }
+	})
+	if material <= endgameMaterial { return GamePhase_Endgame }
+	if position.fullmoveNumber <= openingMoves { return GamePhase_Opening }
+	return GamePhase_Middlegame
+}
+
+// PhaseTimes records how a player spends its time in each phase of the game
+type PhaseTimes struct {
+	moves map[GamePhase]int
+	spent map[GamePhase]time.Duration
+}
+
+func NewPhaseTimes() *PhaseTimes {
+	return &PhaseTimes{ map[GamePhase]int {}, map[GamePhase]time.Duration {} }
+}
+
+// Add records a move played in phase
+func (t *PhaseTimes) Add(phase GamePhase, spent time.Duration) {
+	t.moves[phase] ++
+	t.spent[phase] += spent
+}
+
+// Average returns the average time spent on a move in phase
+func (t *PhaseTimes) Average(phase GamePhase) time.Duration {
+	if t.moves[phase] == 0 { return 0 }
+	return t.spent[phase] / time.Duration(t.moves[phase])
+}
+
+func (t *PhaseTimes) String() string {
+	text := ""
+	for _, phase := range gamePhases {
+		if t.moves[phase] == 0 { continue }
+		if text != "" { text += ", " }
+		text += fmt.Sprint(phase, " ", t.moves[phase], " moves in ", t.spent[phase].Round(time.Millisecond),
+			" (", t.Average(phase).Round(time.Millisecond), " each)")
+	}
+	if text == "" { text = "no moves" }
+	return text
+}
+
+func (t *PhaseTimes) MarshalJSON() ([]byte, error) {
+	type phaseTime struct {
+		Moves int `json:"moves"`
+		SpentMs int64 `json:"spent_ms"`
+	}
+	phases := map[string]phaseTime {}
+	for _, phase := range gamePhases {
+		phases[phase.String()] = phaseTime{ t.moves[phase], t.spent[phase].Milliseconds() }
+	}
+	return json.Marshal(phases)
+}
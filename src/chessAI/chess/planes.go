@@ -0,0 +1,71 @@
+package chess
+
+import "math"
+
+// Input planes for machine learning: each plane has a value for each of the 64 squares, in the same order as the
+// board (a8, b8, ... h8, a7, ... h1), and the planes are laid out one after the other in a flat slice
+const (
+	Plane_WhitePieces = 0 // 6 planes, one per piece in planePieces order, 1 where there's such a piece
+	Plane_BlackPieces = 6
+	Plane_SideToMove = 12 // all 1 when white is to move, all 0 otherwise
+	Plane_Castling = 13 // 4 planes, in FEN order: white king side, white queen side, black king side, black queen side
+	Plane_EnPassant = 17 // 1 on the square a pawn can move to when capturing en-passant
+	Plane_HalfmoveClock = 18 // the halfmove clock on every square, up to 255
+	Plane_FullmoveNumber = 19 // the fullmove number on every square, up to 255
+	PlaneCount = 20
+)
+
+const planeSize = 64
+
+var planePieces = []Piece{ Piece_Pawn, Piece_Knight, Piece_Bishop, Piece_Rock, Piece_Queen, Piece_King }
+
+func fillPlane(planes []byte, plane int, value int) {
+	value = int(math.Min(float64(value), math.MaxUint8))
+	for i := 0; i < planeSize; i ++ {
+		planes[plane * planeSize + i] = byte(value)
+	}
+}
+
+func boolValue(b bool) int {
+	if b { return 1 }
+	return 0
+}
+
+// EncodePlanes converts a position to PlaneCount planes of 64 bytes each
+func EncodePlanes(position Position) []byte {
+	planes := make([]byte, PlaneCount * planeSize)
+
+	ForEachPiece(position.board, func(pos Square, info PieceInfo) {
+		plane := Plane_WhitePieces
+		if info.color == PieceColor_Black { plane = Plane_BlackPieces }
+		for i, piece := range planePieces {
+			if piece == info.piece { planes[(plane + i) * planeSize + pos.x + pos.y * 8] = 1 }
+		}
+	})
+
+	fillPlane(planes, Plane_SideToMove, boolValue(position.sideToMove == PieceColor_White))
+
+	rights := position.CastlingRights()
+	for i, right := range []bool{ rights.whiteKingSide, rights.whiteQueenSide, rights.blackKingSide, rights.blackQueenSide } {
+		fillPlane(planes, Plane_Castling + i, boolValue(right))
+	}
+
+	if square, ok := position.EnPassantSquare(); ok {
+		planes[Plane_EnPassant * planeSize + square.x + square.y * 8] = 1
+	}
+
+	fillPlane(planes, Plane_HalfmoveClock, position.halfmoveClock)
+	fillPlane(planes, Plane_FullmoveNumber, position.fullmoveNumber)
+
+	return planes
+}
+
+// EncodePlanesFloat32 is EncodePlanes with the values as floats, as most training pipelines take them
+func EncodePlanesFloat32(position Position) []float32 {
+	planes := EncodePlanes(position)
+	values := make([]float32, len(planes))
+	for i, value := range planes {
+		values[i] = float32(value)
+	}
+	return values
+}
@@ -0,0 +1,34 @@
+package chess
+
+import "strings"
+import "testing"
+
+// the reference keys of the Polyglot book format, after playing the moves from the initial position
+var polyglotTests = []struct {
+	moves string
+	key uint64
+}{
+	{ "", 0x463b96181691fc9c },
+	{ "e2e4", 0x823c9b50fd114196 },
+	{ "e2e4 d7d5", 0x0756b94461c50fb0 },
+	{ "e2e4 d7d5 e4e5", 0x662fafb965db29d4 },
+	{ "e2e4 d7d5 e4e5 f7f5", 0x22a48b5a8e47ff78 },
+	{ "e2e4 d7d5 e4e5 f7f5 e1e2", 0x652a607ca3f242c1 },
+	{ "e2e4 d7d5 e4e5 f7f5 e1e2 e8f7", 0x00fdd303c946bdd9 },
+	{ "a2a4 b7b5 h2h4 b5b4 c2c4", 0x3c8123ea7b067637 },
+	{ "a2a4 b7b5 h2h4 b5b4 c2c4 b4c3 a1a3", 0x5c3f9b829b279560 },
+}
+
+func TestPolyglotKey(t *testing.T) {
+	for _, test := range polyglotTests {
+		position := InitialPosition(false)
+		for _, text := range strings.Fields(test.moves) {
+			move, ok := ParseMove(position, text)
+			if !ok { t.Fatal(test.moves, ": not a legal move:", text) }
+			position = position.Play(move)
+		}
+		if key := PolyglotKey(position); key != test.key {
+			t.Errorf("%s: key %016x, want %016x", test.moves, key, test.key)
+		}
+	}
+}
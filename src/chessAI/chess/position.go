@@ -0,0 +1,119 @@
+package chess
+
+// Position is everything needed to know the state of a game: the board (which also keeps castling and en-passant
+// info in the piece status bits), the color that moves next, and the move clocks
+type Position struct {
+	board Board
+	sideToMove PieceColor
+	halfmoveClock int // moves since the last capture or pawn move
+	fullmoveNumber int // starts at 1, incremented after every black move
+}
+
+// positionKey identifies a position regardless of its clocks, and of how its squares became empty
+type positionKey struct {
+	board Board
+	sideToMove PieceColor
+}
+
+// CastlingRights tells which castling moves are still possible later in the game, regardless of whether they can
+// be done right now
+type CastlingRights struct {
+	whiteKingSide, whiteQueenSide, blackKingSide, blackQueenSide bool
+}
+
+func InitialPosition(testBoard bool) Position {
+	return Position{ InitialBoard(testBoard), PieceColor_White, 0, 1 }
+}
+
+func (p Position) key() positionKey {
+	// empty squares keep the status and color of the last piece that left them
+	board := p.board
+	occupied := occupiedBits(board)
+	board[PieceStatusBits] &= occupied
+	board[PieceStatusBits + 1] &= occupied
+	return positionKey{ board, p.sideToMove }
+}
+
+// Play returns the position after a move (given by the resulting board) is played
+func (p Position) Play(move Board) Position {
+	// en-passant captures are only possible right after the double push, so the opponent's pawns lose that status
+	// now; otherwise it would still be seen when counting the opponent's moves in the evaluation
+	next := Position{ resetPawnsStatus(move, !p.sideToMove), !p.sideToMove, p.halfmoveClock + 1, p.fullmoveNumber }
+
+	isCapture := countPieces(move, !p.sideToMove) < countPieces(p.board, !p.sideToMove)
+	isPawnMove := pieceBits(move, Piece_Pawn, p.sideToMove) != pieceBits(p.board, Piece_Pawn, p.sideToMove)
+	if isCapture || isPawnMove { next.halfmoveClock = 0 }
+	if p.sideToMove == PieceColor_Black { next.fullmoveNumber ++ }
+
+	return next
+}
+
+// canStillCastle tells whether the king and the rock on one side haven't moved yet
+func canStillCastle(board Board, color PieceColor, direction int) bool {
+	row := 0
+	if color == PieceColor_White { row = 7 }
+	rockPos := Square{ 0, row }
+	if direction == 1 { rockPos.x = 7 }
+
+	king := GetBoardAt(board, Square{ 4, row })
+	rock := GetBoardAt(board, rockPos)
+	return king == PieceInfo{ Piece_King, PieceStatus_Default, color } && rock == PieceInfo{ Piece_Rock, PieceStatus_Default, color }
+}
+
+// String writes the castling rights as in FEN: "KQkq", "Kq", or "-" if there are none
+func (r CastlingRights) String() string {
+	castling := ""
+	if r.whiteKingSide { castling += "K" }
+	if r.whiteQueenSide { castling += "Q" }
+	if r.blackKingSide { castling += "k" }
+	if r.blackQueenSide { castling += "q" }
+	if castling == "" { castling = "-" }
+	return castling
+}
+
+// Board returns the pieces of the position
+func (p Position) Board() Board {
+	return p.board
+}
+
+// SideToMove returns the color of the player who moves next
+func (p Position) SideToMove() PieceColor {
+	return p.sideToMove
+}
+
+// FullmoveNumber returns the number of the move being played, which starts at 1 and grows after Black moves
+func (p Position) FullmoveNumber() int {
+	return p.fullmoveNumber
+}
+
+// HalfmoveClock returns the half moves played since the last capture or pawn move, for the fifty move rule
+func (p Position) HalfmoveClock() int {
+	return p.halfmoveClock
+}
+
+func (p Position) CastlingRights() CastlingRights {
+	return CastlingRights{
+		canStillCastle(p.board, PieceColor_White, 1), canStillCastle(p.board, PieceColor_White, -1),
+		canStillCastle(p.board, PieceColor_Black, 1), canStillCastle(p.board, PieceColor_Black, -1),
+	}
+}
+
+// EnPassantSquare returns the square a pawn can move to when capturing en-passant, if any
+func (p Position) EnPassantSquare() (square Square, ok bool) {
+	for _, pos := range GetPieces(p.board, Piece_Pawn, !p.sideToMove) {
+		if GetBoardAt(p.board, pos).status != PieceStatus_EnPassantAllowed { continue }
+
+		// the square right behind the pawn that moved two squares
+		square = Square{ pos.x, pos.y - 1 }
+		if p.sideToMove == PieceColor_Black { square.y = pos.y + 1 }
+		return square, true
+	}
+	return
+}
+
+// LegalMoves returns all the moves available to the side to move
+func LegalMoves(position Position) []Board {
+	filterCheckMoves := true
+	quickMode := false
+	return GetAllPossibleMoves(position.board, position.sideToMove, filterCheckMoves, quickMode)
+}
@@ -0,0 +1,57 @@
+package chess
+
+import "sort"
+
+type NamedPosition struct {
+	fen string
+	description string
+}
+
+// namedPositions is a library of well known positions, so benchmarks, docs and bug reports can refer to them by name
+var namedPositions = map[string]NamedPosition {
+	"start" : { "rnbqkbnr/pppppppp/8/8/8/8/PPPPPPPP/RNBQKBNR w KQkq - 0 1", "initial position" },
+
+	// perft reference positions, see https://www.chessprogramming.org/Perft_Results
+	"kiwipete" : { "r3k2r/p1ppqpb1/bn2pnp1/3PN3/1p2P3/2N2Q1p/PPPBBPPP/R3K2R w KQkq - 0 1", "perft position 2, lots of castling and en-passant" },
+	"perft3" : { "8/2p5/3p4/KP5r/1R3p1k/8/4P1P1/8 w - - 0 1", "perft position 3, rock endgame with en-passant checks" },
+	"perft4" : { "r3k2r/Pppp1ppp/1b3nbN/nP6/BBP1P3/q4N2/Pp1P2PP/R2Q1RK1 w kq - 0 1", "perft position 4, promotions and castling" },
+	"perft5" : { "rnbq1k1r/pp1Pbppp/2p5/8/2B5/8/PPP1NnPP/RNBQK2R w KQ - 1 8", "perft position 5" },
+	"perft6" : { "r4rk1/1pp1qppp/p1np1n2/2b1p1B1/2B1P1b1/P1NP1N2/1PP1QPPP/R4RK1 w - - 0 10", "perft position 6, quiet middlegame" },
+
+	// zugzwang tests
+	"trebuchet" : { "8/8/8/2Kp4/3Pk3/8/8/8 w - - 0 1", "mutual zugzwang, the side to move loses its pawn" },
+
+	// famous studies
+	"reti" : { "7K/8/k1P5/7p/8/8/8/8 w - - 0 1", "Reti's study, white to play and draw" },
+	"saavedra" : { "8/8/1KP5/3r4/8/8/8/k7 w - - 0 1", "Saavedra position, white to play and win" },
+
+	// mate exercises
+	"backrank-mate" : { "6k1/5ppp/8/8/8/8/8/R5K1 w - - 0 1", "white mates in 1" },
+	"scholars-mate" : { "r1bqkb1r/pppp1ppp/2n2n2/4p2Q/2B1P3/8/PPPP1PPP/RNB1K1NR w KQkq - 4 4", "white mates in 1" },
+}
+
+// GetNamedPosition returns the position of the library with the given name, and tells whether there is one
+func GetNamedPosition(name string) (position NamedPosition, ok bool) {
+	position, ok = namedPositions[name]
+	return
+}
+
+// FEN returns the position in FEN
+func (p NamedPosition) FEN() string {
+	return p.fen
+}
+
+// Description tells what the position is known for
+func (p NamedPosition) Description() string {
+	return p.description
+}
+
+// NamedPositionNames returns the names of all the positions in the library, sorted
+func NamedPositionNames() []string {
+	names := make([]string, 0, len(namedPositions))
+	for name := range namedPositions {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
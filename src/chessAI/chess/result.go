@@ -0,0 +1,172 @@
+package chess
+
+import "encoding/json"
+import "fmt"
+import "time"
+
+type Termination uint8
+
+const (
+	Termination_None Termination = iota // game not finished
+	Termination_Checkmate
+	Termination_Stalemate
+	Termination_TimeForfeit
+	Termination_InsufficientMaterial
+	Termination_Adjourned // the game isn't finished, it will be resumed later
+	Termination_Repetition // the same position was reached too many times
+	Termination_MoveRule // too many moves without captures or pawn moves
+)
+
+var terminationNamesMap = map[Termination]string {
+	Termination_None : "unterminated", Termination_Checkmate : "checkmate", Termination_Stalemate : "stalemate",
+	Termination_TimeForfeit : "time forfeit", Termination_InsufficientMaterial : "insufficient material",
+	Termination_Adjourned : "adjourned", Termination_Repetition : "repetition",
+	Termination_MoveRule : "move rule",
+}
+
+func (t Termination) String() string {
+	return terminationNamesMap[t]
+}
+
+// Result describes how a game ended
+type Result struct {
+	termination Termination
+	draw bool
+	winner PieceColor // only meaningful if the game finished and it isn't a draw
+	finalPosition Position
+	plies int // half moves played
+	white, black string // player names
+	computerTime *PhaseTimes // time used by the computer in each phase, nil if unknown
+	history *moveHistory // how the game got to finalPosition, nil if unknown
+	clock *Clock // as it was when the game ended, nil if the game was played without one
+}
+
+// GetResult tells whether the game is finished in position, and how
+func GetResult(position Position, plies int) (result Result, finished bool) {
+	filterCheckMoves := true
+	availableMoveCount := GetPossibleMoveCount(position.board, position.sideToMove, filterCheckMoves)
+	finished, draw, winningColor := GetGameStatus(position, availableMoveCount)
+
+	result = Result{ Termination_None, draw, winningColor, position, plies, "", "", nil, nil, nil }
+	if finished && draw && availableMoveCount == 0 { result.termination = Termination_Stalemate }
+	if finished && draw && availableMoveCount > 0 { result.termination = Termination_InsufficientMaterial }
+	if finished && !draw { result.termination = Termination_Checkmate }
+
+	return
+}
+
+// timeForfeitResult is the result when loser runs out of time, leaving the game in position
+func timeForfeitResult(position Position, loser PieceColor, plies int) Result {
+	return Result{ Termination_TimeForfeit, false, !loser, position, plies, "", "", nil, nil, nil }
+}
+
+// Termination returns how the game ended, Termination_None if it didn't
+func (r Result) Termination() Termination {
+	return r.termination
+}
+
+// Draw tells whether the game ended in a draw
+func (r Result) Draw() bool {
+	return r.draw
+}
+
+// Winner returns the color that won, only meaningful if the game finished and it isn't a draw
+func (r Result) Winner() PieceColor {
+	return r.winner
+}
+
+// FinalPosition returns the position the game ended in
+func (r Result) FinalPosition() Position {
+	return r.finalPosition
+}
+
+// Plies returns the half moves played
+func (r Result) Plies() int {
+	return r.plies
+}
+
+// Clock returns the game clock as it was when the game ended, nil if the game was played without one
+func (r Result) Clock() *Clock {
+	return r.clock
+}
+
+func (r Result) Finished() bool {
+	return r.termination != Termination_None && r.termination != Termination_Adjourned
+}
+
+// Score returns the result as written in PGN: "1-0", "0-1", "1/2-1/2" or "*"
+func (r Result) Score() string {
+	if !r.Finished() { return "*" }
+	if r.draw { return "1/2-1/2" }
+	if r.winner == PieceColor_White { return "1-0" }
+	return "0-1"
+}
+
+func (r Result) String() string {
+	if r.termination == Termination_Adjourned { return "adjourned" }
+	if !r.Finished() { return "unfinished" }
+	if r.draw { return fmt.Sprint("draw by ", r.termination) }
+	return fmt.Sprint(r.winner, " wins by ", r.termination)
+}
+
+// PGNTags returns the PGN tags (name and value) that describe the result
+func (r Result) PGNTags() [][2]string {
+	pgnTermination := "normal"
+	if !r.Finished() { pgnTermination = "unterminated" }
+	if r.termination == Termination_TimeForfeit { pgnTermination = "time forfeit" }
+
+	timeControl := "-"
+	if r.clock != nil { timeControl = r.clock.TimeControl() }
+
+	tags := [][2]string{
+		{ "White", r.white },
+		{ "Black", r.black },
+		{ "Result", r.Score() },
+		{ "Termination", pgnTermination },
+		{ "PlyCount", fmt.Sprint(r.plies) },
+		{ "TimeControl", timeControl },
+	}
+	if r.clock != nil {
+		tags = append(tags, [2]string{ "WhiteClock", pgnClock(r.clock.Remaining(PieceColor_White)) },
+			[2]string{ "BlackClock", pgnClock(r.clock.Remaining(PieceColor_Black)) })
+	}
+	return tags
+}
+
+// pgnClock writes the time left on a clock as h:mm:ss, as the WhiteClock and BlackClock tags do
+func pgnClock(remaining time.Duration) string {
+	seconds := int64(remaining.Seconds())
+	return fmt.Sprintf("%d:%02d:%02d", seconds / 3600, seconds / 60 % 60, seconds % 60)
+}
+
+func (r Result) MarshalJSON() ([]byte, error) {
+	winner := ""
+	if r.Finished() && !r.draw { winner = r.winner.String() }
+	timeControl := ""
+	var whiteLeft, blackLeft *int64
+	if r.clock != nil {
+		white, black := r.clock.Remaining(PieceColor_White).Milliseconds(), r.clock.Remaining(PieceColor_Black).Milliseconds()
+		timeControl, whiteLeft, blackLeft = r.clock.TimeControl(), &white, &black
+	}
+
+	return json.Marshal(struct {
+		White string `json:"white"`
+		Black string `json:"black"`
+		Result string `json:"result"`
+		Winner string `json:"winner,omitempty"`
+		Termination string `json:"termination"`
+		FinalFEN string `json:"final_fen"`
+		Material string `json:"material"`
+		Plies int `json:"plies"`
+		HalfmoveClock int `json:"halfmove_clock"`
+		FullmoveNumber int `json:"fullmove_number"`
+		ComputerTime *PhaseTimes `json:"computer_time,omitempty"`
+		TimeControl string `json:"time_control,omitempty"`
+		WhiteTimeLeftMs *int64 `json:"white_time_left_ms,omitempty"`
+		BlackTimeLeftMs *int64 `json:"black_time_left_ms,omitempty"`
+	}{
+		r.white, r.black, r.Score(), winner, r.termination.String(), ToFEN(r.finalPosition),
+		GetMaterialSignature(r.finalPosition.board).String(), r.plies,
+		r.finalPosition.halfmoveClock, r.finalPosition.fullmoveNumber, r.computerTime, timeControl, whiteLeft, blackLeft,
+	})
+}
@@ -0,0 +1,54 @@
+package chess
+
+// RulesProfile decides how the draw rules are applied. Dead positions (see InsufficientMaterial) always end the
+// game right away.
+type RulesProfile struct {
+	name string
+	automaticRepetitions int // a position reached this many times ends the game in a draw
+	automaticMoveRule int // half moves without captures or pawn moves that end the game in a draw
+	claimRepetitions int // a position reached this many times lets the side to move claim a draw, 0 for no claims
+	claimMoveRule int // half moves without captures or pawn moves that let the side to move claim a draw, 0 for no claims
+	autoQueen bool // whether pawns can be promoted to queens without asking
+}
+
+var rulesProfiles = map[string]RulesProfile {
+	// threefold repetition and the fifty move rule have to be claimed, only fivefold repetition and the seventy-five
+	// move rule end the game by themselves
+	"fide" : { "fide", 5, 150, 3, 100, false },
+	// draws as soon as they're possible
+	"casual" : { "casual", 3, 100, 0, 0, true },
+}
+
+// DefaultRules returns the profile games use unless they're given another one: casual
+func DefaultRules() RulesProfile {
+	return rulesProfiles["casual"]
+}
+
+// GetRules returns the profile with the given name, casual or fide, and tells whether there is one
+func GetRules(name string) (rules RulesProfile, ok bool) {
+	rules, ok = rulesProfiles[name]
+	return
+}
+
+func (r RulesProfile) String() string {
+	return r.name
+}
+
+// AutoQueen tells whether the rules let pawns be promoted to queens without asking
+func (r RulesProfile) AutoQueen() bool {
+	return r.autoQueen
+}
+
+// automaticDraw tells whether the game is drawn in position, reached repetitions times, without anyone claiming it
+func (r RulesProfile) automaticDraw(position Position, repetitions int) (termination Termination, draw bool) {
+	if repetitions >= r.automaticRepetitions { return Termination_Repetition, true }
+	if position.halfmoveClock >= r.automaticMoveRule { return Termination_MoveRule, true }
+	return
+}
+
+// claimableDraw tells whether the side to move can claim a draw in position, reached repetitions times
+func (r RulesProfile) claimableDraw(position Position, repetitions int) (termination Termination, draw bool) {
+	if r.claimRepetitions > 0 && repetitions >= r.claimRepetitions { return Termination_Repetition, true }
+	if r.claimMoveRule > 0 && position.halfmoveClock >= r.claimMoveRule { return Termination_MoveRule, true }
+	return
+}
@@ -0,0 +1,89 @@
+package chess
+
+import "strings"
+
+// ToSAN writes the move from position to newBoard in standard algebraic notation: "e4", "Nbd7", "exd5", "O-O",
+// "e8=Q+", "Qh7#"
+func ToSAN(position Position, newBoard Board) string {
+	moves := GenerateMoves(position)
+	for _, m := range moves {
+		if m.board == newBoard { return toSAN(position, m, moves) }
+	}
+	return toSAN(position, moveSquares(position, newBoard), moves)
+}
+
+// toSAN is ToSAN for a move of GenerateMoves, given the legal moves of position, which tell whether other pieces
+// could make the same move
+func toSAN(position Position, m MoveInfo, moves []MoveInfo) string {
+	m = addCheckFlags(position, m)
+
+	var san string
+	switch {
+	case m.Is(MoveFlag_Castle) && m.to.x > m.from.x:
+		san = "O-O"
+	case m.Is(MoveFlag_Castle):
+		san = "O-O-O"
+	case m.piece == Piece_Pawn:
+		if m.Is(MoveFlag_Capture) { san = m.from.String()[:1] + "x" }
+		san += m.to.String()
+		if m.Is(MoveFlag_Promotion) { san += "=" + strings.ToUpper(pieceLetterMap[m.promotion]) }
+	default:
+		san = strings.ToUpper(pieceLetterMap[m.piece]) + sanDisambiguation(m, moves)
+		if m.Is(MoveFlag_Capture) { san += "x" }
+		san += m.to.String()
+	}
+
+	if m.Is(MoveFlag_Mate) {
+		san += "#"
+	} else if m.Is(MoveFlag_Check) {
+		san += "+"
+	}
+	return san
+}
+
+// sanDisambiguation returns what has to be added after the piece letter so no other piece of the same kind could
+// make the move: nothing, the file, the rank, or both
+func sanDisambiguation(m MoveInfo, moves []MoveInfo) string {
+	ambiguous, sameFile, sameRank := false, false, false
+	for _, other := range moves {
+		if other.piece != m.piece || other.to != m.to || other.from == m.from { continue }
+
+		ambiguous = true
+		if other.from.x == m.from.x { sameFile = true }
+		if other.from.y == m.from.y { sameRank = true }
+	}
+
+	from := m.from.String()
+	switch {
+	case !ambiguous:
+		return ""
+	case !sameFile:
+		return from[:1]
+	case !sameRank:
+		return from[1:]
+	}
+	return from
+}
+
+// ParseSAN finds the legal move written in standard algebraic notation. The check and mate signs, and any
+// annotation like "!" or "?", are optional, as are the "=" of promotions and "e.p." after en passant captures;
+// the promotion piece can be lowercase, and castling can be written with zeros.
+func ParseSAN(position Position, text string) (move Board, ok bool) {
+	return parseSAN(position, text, GenerateMoves(position))
+}
+
+// parseSAN is ParseSAN choosing among moves, the legal moves in position
+func parseSAN(position Position, text string, moves []MoveInfo) (move Board, ok bool) {
+	clean := func(san string) string {
+		san = strings.TrimRight(san, "+#!?")
+		san = strings.TrimSpace(strings.TrimSuffix(san, "e.p."))
+		if i := strings.Index(san, "="); i >= 0 { san = san[:i] + strings.ToUpper(san[i:]) }
+		return strings.NewReplacer("0", "O", "=", "").Replace(san)
+	}
+	text = clean(text)
+
+	for _, m := range moves {
+		if clean(toSAN(position, m, moves)) == text { return m.board, true }
+	}
+	return
+}
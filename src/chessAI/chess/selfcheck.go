@@ -0,0 +1,72 @@
+//go:build !rulesonly
+
+package chess
+
+import "fmt"
+import "math/bits"
+
+// selfCheckInterval is how many nodes the search visits between two self checks
+var selfCheckInterval = 1 << 14
+
+// selfCheckEntries is how many transposition table entries each self check looks at
+var selfCheckEntries = 256
+
+// checkBoard returns what's wrong with a board, or an empty string if nothing is: every occupied square has a valid
+// piece, there's one king of each color, and the material signature counts every piece on the board
+func checkBoard(board Board) string {
+	occupied := occupiedBits(board)
+	for _, pos := range SquaresFromBits(occupied) {
+		if info := GetBoardAt(board, pos); info.piece > Piece_Queen { return fmt.Sprint("invalid piece at ", pos) }
+	}
+
+	signature := GetMaterialSignature(board)
+	if signature.Count(Piece_King, PieceColor_White) != 1 || signature.Count(Piece_King, PieceColor_Black) != 1 {
+		return fmt.Sprint("not one king of each color, material ", signature)
+	}
+	pieces := 0
+	for _, color := range []PieceColor{ PieceColor_White, PieceColor_Black } {
+		for _, piece := range planePieces {
+			pieces += signature.Count(piece, color)
+		}
+	}
+	if pieces != bits.OnesCount64(occupied) {
+		return fmt.Sprint("material ", signature, " doesn't match the ", bits.OnesCount64(occupied), " occupied squares")
+	}
+	return ""
+}
+
+// selfCheck verifies the invariants of the search in position, and panics if one doesn't hold, so the bug shows up
+// close to where it happened (and, in games, gets into the bug report of SafeSearchGame). It's only called when
+// selfCheckEnabled.
+func (s *search) selfCheck(position Position) {
+	fail := func(problem string) {
+		panic(fmt.Sprint("self check failed after ", s.nodes, " nodes in ", ToFEN(position), ": ", problem))
+	}
+
+	if problem := checkBoard(position.board); problem != "" { fail(problem) }
+
+	// there's no incremental hash to compare with, so the hash is checked against the position read back from FEN
+	if fenPosition, err := ParseFEN(ToFEN(position)); err != nil {
+		fail("can't read back its FEN: " + err.Error())
+	} else if ZobristHash(fenPosition) != ZobristHash(position) {
+		fail(fmt.Sprintf("hash %016x doesn't match the hash %016x of its FEN", ZobristHash(position), ZobristHash(fenPosition)))
+	}
+
+	if s.table.used > len(s.table.entries) {
+		fail(fmt.Sprint("transposition table has ", s.table.used, " entries used, over its ", len(s.table.entries)))
+	}
+
+	// a different slice of the table is checked each time
+	for i := 0; i < selfCheckEntries && i < len(s.table.entries); i ++ {
+		entry := s.table.entries[(s.nodes / selfCheckInterval * selfCheckEntries + i) % len(s.table.entries)]
+		if entry.depth == 0 { continue }
+
+		if int(entry.score) < lowestScore || int(entry.score) > biggestScore {
+			fail(fmt.Sprint("transposition table score ", entry.score, " out of range"))
+		}
+		if entry.depth < 0 || entry.bound > Bound_Upper { fail(fmt.Sprint("transposition table entry with depth ", entry.depth, " and bound ", entry.bound)) }
+		if m := entry.move; m.from > 63 || m.to > 63 || m.from == m.to {
+			fail(fmt.Sprint("transposition table best move from ", m.from, " to ", m.to))
+		}
+	}
+}
@@ -0,0 +1,6 @@
+//go:build !selfcheck && !rulesonly
+
+package chess
+
+// selfCheckEnabled makes the search check its invariants as it goes; build with -tags selfcheck to enable it
+const selfCheckEnabled = false
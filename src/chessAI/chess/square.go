@@ -0,0 +1,90 @@
+package chess
+
+import "fmt"
+
+/*
+
+Squares use x for the file and y for the rank, but y grows downwards: y = 0 is the 8th rank (where the black pieces
+start) and y = 7 is the 1st rank. The square index used by Board is x + y * 8, so index 0 is a8 and index 63 is h1.
+
+*/
+
+type Square struct {
+	x, y int
+}
+
+// SquareFromIndex returns the square for a Board bit index (0 is a8, 63 is h1)
+func SquareFromIndex(index int) Square {
+	return Square{ index % 8, index / 8 }
+}
+
+// SquareFromFileRank returns the square for a file (0 is the a file) and a rank (1 to 8, as in algebraic notation)
+func SquareFromFileRank(file, rank int) Square {
+	return Square{ file, 8 - rank }
+}
+
+// SquareFromString parses a square in algebraic notation, e.g. "e4"
+func SquareFromString(name string) (square Square, err error) {
+	if len(name) == 2 {
+		square = SquareFromFileRank(int(name[0]) - 'a', int(name[1]) - '0')
+		if SquareInBoard(square) { return }
+	}
+	err = fmt.Errorf("invalid square %q", name)
+	return
+}
+
+// Index returns the Board bit index for the square
+func (s Square) Index() int {
+	return s.x + s.y * 8
+}
+
+func (s Square) File() int {
+	return s.x
+}
+
+// Rank returns the rank of the square, from 1 to 8
+func (s Square) Rank() int {
+	return 8 - s.y
+}
+
+// String returns the square in algebraic notation, e.g. "e4"
+func (s Square) String() string {
+	if !SquareInBoard(s) { return fmt.Sprintf("(%d, %d)", s.x, s.y) }
+	return fmt.Sprintf("%c%d", 'a' + s.x, s.Rank())
+}
+
+func SquareInBoard(pos Square) bool {
+	if pos.x < 0 || pos.x > 7 || pos.y < 0 || pos.y > 7 { return false }
+	return true
+}
+
+func SquareAdd(pos Square, move Move) Square {
+	return Square{ pos.x + move.x, pos.y + move.y }
+}
+
+func SquareDiff(pos Square, move Move) Square {
+	return Square{ pos.x - move.x, pos.y - move.y }
+}
+
+var allSquares = func() []Square {
+	squares := make([]Square, 64)
+	for i := range squares {
+		squares[i] = SquareFromIndex(i)
+	}
+	return squares
+}()
+
+// AllSquares returns the 64 squares in index order, a8 first; the slice must not be modified
+func AllSquares() []Square {
+	return allSquares
+}
+
+// SquaresFromBits returns the squares whose bits are set, using the same order as Board
+func SquaresFromBits(bits uint64) []Square {
+	squares := []Square{}
+	for i := 0; bits != 0; i ++ {
+		if bits & 1 == 1 { squares = append(squares, SquareFromIndex(i)) }
+		bits >>= 1
+	}
+	return squares
+}
@@ -0,0 +1,79 @@
+package chess
+
+import "fmt"
+
+// MoveStats holds game tree statistics for a set of positions
+type MoveStats struct {
+	positions int
+	moves int // legal moves
+	captures int
+	checks int
+	minMoves, maxMoves int
+}
+
+// GetMoveStats computes the statistics for the moves available in a single position
+func GetMoveStats(position Position) (stats MoveStats) {
+	moves := LegalMoveInfos(position)
+
+	stats.positions = 1
+	stats.moves = len(moves)
+	stats.minMoves, stats.maxMoves = len(moves), len(moves)
+
+	for _, move := range moves {
+		if move.Is(MoveFlag_Capture) { stats.captures ++ }
+		if move.Is(MoveFlag_Check) { stats.checks ++ }
+	}
+
+	return
+}
+
+// Add merges the statistics of another set of positions
+func (s *MoveStats) Add(other MoveStats) {
+	if s.positions == 0 || other.minMoves < s.minMoves { s.minMoves = other.minMoves }
+	if other.maxMoves > s.maxMoves { s.maxMoves = other.maxMoves }
+	s.positions += other.positions
+	s.moves += other.moves
+	s.captures += other.captures
+	s.checks += other.checks
+}
+
+// BranchingFactor returns the average number of legal moves per position
+func (s MoveStats) BranchingFactor() float64 {
+	if s.positions == 0 { return 0 }
+	return float64(s.moves) / float64(s.positions)
+}
+
+// Positions returns the number of positions the statistics are about
+func (s MoveStats) Positions() int {
+	return s.positions
+}
+
+// Moves returns the number of legal moves, in all the positions
+func (s MoveStats) Moves() int {
+	return s.moves
+}
+
+// Captures returns how many of the legal moves are captures
+func (s MoveStats) Captures() int {
+	return s.captures
+}
+
+// Checks returns how many of the legal moves give check
+func (s MoveStats) Checks() int {
+	return s.checks
+}
+
+// MinMoves returns the number of legal moves of the position with the fewest
+func (s MoveStats) MinMoves() int {
+	return s.minMoves
+}
+
+// MaxMoves returns the number of legal moves of the position with the most
+func (s MoveStats) MaxMoves() int {
+	return s.maxMoves
+}
+
+func (s MoveStats) String() string {
+	return fmt.Sprintf("positions %d, moves %d (min %d, max %d, average %.2f), captures %d, checks %d",
+		s.positions, s.moves, s.minMoves, s.maxMoves, s.BranchingFactor(), s.captures, s.checks)
+}
@@ -0,0 +1,139 @@
+//go:build !rulesonly
+
+package chess
+
+import "unsafe"
+
+// Bound tells how a score stored in the transposition table relates to the true score of the position, which
+// depends on the alpha beta window it was searched with
+type Bound uint8
+
+const (
+	Bound_Exact Bound = iota // the score is the true score
+	Bound_Lower // a move reached beta and cut the search off, the true score is at least the score
+	Bound_Upper // no move raised alpha, the true score is at most the score
+)
+
+var boundNamesMap = map[Bound]string {
+	Bound_Exact : "exact", Bound_Lower : "lower", Bound_Upper : "upper",
+}
+
+func (b Bound) String() string {
+	return boundNamesMap[b]
+}
+
+// ttEntry is what the transposition table knows about a position
+type ttEntry struct {
+	hash uint64 // ZobristHash of the position
+	move moveKey // best move found
+	score int32 // from the side to move's point of view
+	depth int8 // depth the position was searched to, 0 for empty entries
+	bound Bound
+	generation uint8 // of the search that stored it
+}
+
+// ttEntryBytes is the memory used by each transposition table entry
+const ttEntryBytes = int(unsafe.Sizeof(ttEntry{}))
+
+// TranspositionTable caches what the search found about positions already searched: their score, the depth and
+// window it was searched with, and their best move. It's a fixed number of entries, which fit in a memory budget,
+// indexed by the positions' Zobrist hashes. When two positions need the same entry, the one searched deeper keeps it,
+// unless it was stored by an earlier search. A table can be kept from one search to the next, as in a game: what the
+// search of a move found about the positions after it still holds when searching the next move.
+type TranspositionTable struct {
+	entries []ttEntry
+	used int // entries that aren't empty
+	generation uint8 // of the current search, to tell the entries of earlier searches apart
+}
+
+func NewTranspositionTable(maxMemoryMB int) *TranspositionTable {
+	size := maxMemoryMB * 1024 * 1024 / ttEntryBytes
+	if size < 1 { size = 1 }
+	return &TranspositionTable{ entries: make([]ttEntry, size) }
+}
+
+// probe returns the entry of the position with the given hash, if the table has it
+func (t *TranspositionTable) probe(hash uint64) (entry ttEntry, ok bool) {
+	entry = t.entries[hash % uint64(len(t.entries))]
+	return entry, entry.depth > 0 && entry.hash == hash
+}
+
+// store saves what the search found about a position, replacing what was known about it, or about a position
+// searched less deep or by an earlier search that used the same entry. depth has to be at least 1.
+func (t *TranspositionTable) store(hash uint64, depth int, score int, bound Bound, move moveKey) {
+	entry := &t.entries[hash % uint64(len(t.entries))]
+	if entry.depth > 0 && entry.hash != hash && int(entry.depth) > depth && entry.generation == t.generation { return }
+
+	if entry.depth == 0 { t.used ++ }
+	*entry = ttEntry{ hash, move, int32(score), int8(depth), bound, t.generation }
+}
+
+// newSearch ages the entries stored so far: they can still be used, but any position can take their place
+func (t *TranspositionTable) newSearch() {
+	t.generation ++
+}
+
+// Clear empties the table, as at the start of a new game, where what was stored is of no use anymore
+func (t *TranspositionTable) Clear() {
+	for i := range t.entries {
+		t.entries[i] = ttEntry{}
+	}
+	t.used, t.generation = 0, 0
+}
+
+// mateThreshold tells mate scores apart from the rest: evaluations never get close to checkMateScore
+var mateThreshold = checkMateScore / 2
+
+// scoreToTable converts a score found at a node with depthLeft to the form stored in the table. Mate scores count
+// the depth left where the mate happens, which only means the same thing from the same depth, so they're stored
+// as the distance from the node to the mate instead.
+func scoreToTable(score int, depthLeft int) int {
+	switch {
+	case score >= mateThreshold:
+		return score - depthLeft
+	case score <= - mateThreshold:
+		return score + depthLeft
+	}
+	return score
+}
+
+// scoreFromTable converts a score stored in the table to a score at a node with depthLeft, undoing scoreToTable
+func scoreFromTable(score int, depthLeft int) int {
+	switch {
+	case score >= mateThreshold:
+		return score + depthLeft
+	case score <= - mateThreshold:
+		return score - depthLeft
+	}
+	return score
+}
+
+// Get returns the score stored for position, with the depth it was searched to and its bound. Mate scores are
+// stored as checkMateScore minus the plies from the position to the mate.
+func (t *TranspositionTable) Get(position Position) (score int, depth int, bound Bound, ok bool) {
+	entry, ok := t.probe(ZobristHash(position))
+	return int(entry.score), int(entry.depth), entry.bound, ok
+}
+
+// BestMove returns the best move stored for position, if any; it's always a legal move
+func (t *TranspositionTable) BestMove(position Position) (move Board, ok bool) {
+	entry, ok := t.probe(ZobristHash(position))
+	if !ok { return }
+	m, ok := findMoveKey(position, entry.move)
+	return m.board, ok
+}
+
+// Entries returns the number of entries that aren't empty
+func (t *TranspositionTable) Entries() int {
+	return t.used
+}
+
+// MemoryUsage returns the memory used by the entries that aren't empty, in bytes
+func (t *TranspositionTable) MemoryUsage() int {
+	return t.used * ttEntryBytes
+}
+
+// MemoryBudget returns the maximum memory the table can use, in bytes
+func (t *TranspositionTable) MemoryBudget() int {
+	return len(t.entries) * ttEntryBytes
+}
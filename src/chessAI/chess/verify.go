@@ -0,0 +1,353 @@
+package chess
+
+import "fmt"
+import "math/bits"
+import "math/rand"
+
+// RandomPositions plays random moves from the built-in positions, to get positions for testing
+func RandomPositions(rnd *rand.Rand, count int) []Position {
+	names := NamedPositionNames()
+	positions := make([]Position, 0, count)
+
+	for len(positions) < count {
+		position, err := ParseFEN(namedPositions[names[rnd.Intn(len(names))]].fen)
+		if err != nil { panic(err) }
+
+		plies := rnd.Intn(40)
+		for i := 0; i < plies; i ++ {
+			moves := LegalMoves(position)
+			if len(moves) == 0 { break }
+			position = position.Play(moves[rnd.Intn(len(moves))])
+		}
+		positions = append(positions, position)
+	}
+
+	return positions
+}
+
+// withoutStatus clears the status bits of a board, since quickMode doesn't keep them up to date, and the color of
+// empty squares, which depends on how they became empty
+func withoutStatus(board Board) Board {
+	board[PieceStatusBits] = 0
+	board[PieceStatusBits + 1] &= occupiedBits(board)
+	return board
+}
+
+// compareQuickMode checks that quickMode move generation for the piece at pos gives the same moves as the
+// full generator, except for castling (which quickMode doesn't compute) and the status bits.
+func compareQuickMode(board Board, pos Square, filterCheckMoves bool) (ok bool, quick, full []Board) {
+	info := GetBoardAt(board, pos)
+	quick = GetPossibleMoves(board, pos, info, filterCheckMoves, true)
+	full = GetPossibleMoves(board, pos, info, filterCheckMoves, false)
+
+	counts := map[Board]int {}
+	for _, b := range full {
+		counts[withoutStatus(b)] ++
+	}
+	if info.piece == Piece_King {
+		for _, m := range addCastlingMoves(board, pos, info, []MoveInfo{}) {
+			b := withoutStatus(moveBoard(board, m))
+			if counts[b] > 0 { counts[b] -- }
+		}
+	}
+	for _, b := range quick {
+		counts[withoutStatus(b)] --
+	}
+
+	for _, count := range counts {
+		if count != 0 { return false, quick, full }
+	}
+	return true, quick, full
+}
+
+// listMoves writes moves played in position, one per line, for the errors of the checks
+func listMoves(position Position, moves []Board) string {
+	list := ""
+	for _, b := range moves {
+		list += "\n  " + DescribeMove(position, b)
+	}
+	return list
+}
+
+func verifyQuickMode(positions []Position) error {
+	for i, p := range positions {
+		for _, color := range []PieceColor{ PieceColor_White, PieceColor_Black } {
+			for _, pos := range GetPiecesByColor(p.board, color) {
+				for _, filterCheckMoves := range []bool{ false, true } {
+					ok, quick, full := compareQuickMode(p.board, pos, filterCheckMoves)
+					if ok { continue }
+
+					description := Position{ board: p.board, sideToMove: color }
+					return fmt.Errorf("quickMode divergence in position %d %s for the piece at %v filterCheckMoves %v\nquickMode moves:%s\nfull moves:%s",
+						i, ToFEN(p), pos, filterCheckMoves, listMoves(description, quick), listMoves(description, full))
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// isCaptureOrPromotion tells whether color captured something or promoted a pawn to go from board to newBoard
+func isCaptureOrPromotion(board, newBoard Board, color PieceColor) bool {
+	pawns := bits.OnesCount64(pieceBits(board, Piece_Pawn, color))
+	newPawns := bits.OnesCount64(pieceBits(newBoard, Piece_Pawn, color))
+	return countPieces(newBoard, !color) < countPieces(board, !color) || newPawns < pawns
+}
+
+// verifyCaptures checks that the capture generator gives the same moves as filtering all the legal moves
+func verifyCaptures(positions []Position) error {
+	filterCheckMoves := true
+
+	for i, p := range positions {
+		counts := map[Board]int {}
+		for _, b := range LegalMoves(p) {
+			if isCaptureOrPromotion(p.board, b, p.sideToMove) { counts[b] ++ }
+		}
+		captures := GetAllCaptureMoves(p.board, p.sideToMove, filterCheckMoves)
+		for _, b := range captures {
+			counts[b] --
+		}
+
+		for _, count := range counts {
+			if count == 0 { continue }
+
+			return fmt.Errorf("capture generator divergence in position %d %s\ncaptures generated:%s", i, ToFEN(p),
+				listMoves(p, captures))
+		}
+	}
+	return nil
+}
+
+// verifyChecks checks that the check generator gives the same moves as filtering all the legal moves
+func verifyChecks(positions []Position) error {
+	for i, p := range positions {
+		counts := map[Board]int {}
+		for _, b := range LegalMoves(p) {
+			kingPos := GetPieces(b, Piece_King, !p.sideToMove)[0]
+			if isUnderAttack(b, kingPos, !p.sideToMove) { counts[b] ++ }
+		}
+		checks := GetCheckingMoves(p.board, p.sideToMove)
+		for _, b := range checks {
+			counts[b] --
+		}
+
+		for _, count := range counts {
+			if count == 0 { continue }
+
+			return fmt.Errorf("check generator divergence in position %d %s\nchecks generated:%s", i, ToFEN(p),
+				listMoves(p, checks))
+		}
+	}
+	return nil
+}
+
+// verifyEvasions checks that generating only evasions when in check gives the same moves as filtering all the
+// moves of every piece
+func verifyEvasions(positions []Position) error {
+	filterCheckMoves := true
+	quickMode := false
+
+	for i, p := range positions {
+		counts := map[Board]int {}
+		for _, pos := range GetPiecesByColor(p.board, p.sideToMove) {
+			info := GetBoardAt(p.board, pos)
+			for _, b := range GetPossibleMoves(p.board, pos, info, filterCheckMoves, quickMode) {
+				counts[b] ++
+			}
+		}
+		moves := LegalMoves(p)
+		for _, b := range moves {
+			counts[b] --
+		}
+
+		for _, count := range counts {
+			if count == 0 { continue }
+
+			return fmt.Errorf("evasion generator divergence in position %d %s\nmoves generated:%s", i, ToFEN(p),
+				listMoves(p, moves))
+		}
+	}
+	return nil
+}
+
+// verifyLegality checks that IsLegal accepts exactly the moves generated, trying every piece of the side to move
+// against every square
+func verifyLegality(positions []Position) error {
+	for i, p := range positions {
+		generated := map[string]bool {}
+		for _, b := range LegalMoves(p) {
+			generated[DescribeMove(p, b)[:4]] = true
+		}
+
+		for _, from := range GetPiecesByColor(p.board, p.sideToMove) {
+			for _, to := range AllSquares() {
+				legal, reason := IsLegal(p, FullMove{ from, Move{ to.x - from.x, to.y - from.y } })
+				if legal == generated[from.String() + to.String()] { continue }
+
+				return fmt.Errorf("legality check divergence in position %d %s move %s legal %v %v", i, ToFEN(p),
+					from.String() + to.String(), legal, reason)
+			}
+		}
+	}
+	return nil
+}
+
+// verifyUnique checks that no move is generated twice, by the full generator, the evasion generator or the
+// capture generator
+func verifyUnique(positions []Position) error {
+	filterCheckMoves := true
+
+	for i, p := range positions {
+		lists := map[string][]Board {
+			"legal moves" : LegalMoves(p),
+			"captures" : GetAllCaptureMoves(p.board, p.sideToMove, filterCheckMoves),
+		}
+		for name, moves := range lists {
+			seen := map[Board]bool {}
+			for _, b := range moves {
+				if seen[b] {
+					return fmt.Errorf("duplicated move in %s of position %d %s: %s", name, i, ToFEN(p), DescribeMove(p, b))
+				}
+				seen[b] = true
+			}
+		}
+	}
+	return nil
+}
+
+// verifyFEN checks that converting positions to FEN and back keeps the same pieces and FEN. The status bits may
+// differ: a rock that hasn't moved keeps its castling status even when its king has.
+func verifyFEN(positions []Position) error {
+	for i, p := range positions {
+		fen := ToFEN(p)
+		parsed, err := ParseFEN(fen)
+		if err == nil && ToFEN(parsed) == fen && withoutStatus(parsed.board) == withoutStatus(p.board) { continue }
+
+		return fmt.Errorf("FEN round trip failed in position %d %s %v", i, fen, err)
+	}
+	return nil
+}
+
+// verifyPlanes checks that the input planes have each piece on its square, and nothing else
+func verifyPlanes(positions []Position) error {
+	for i, p := range positions {
+		planes := EncodePlanes(p)
+		for _, pos := range AllSquares() {
+			info := GetBoardAt(p.board, pos)
+			count := 0
+			for plane := Plane_WhitePieces; plane < Plane_SideToMove; plane ++ {
+				if planes[plane * planeSize + pos.x + pos.y * 8] == 0 { continue }
+				count ++
+
+				color := PieceColor_White
+				if plane >= Plane_BlackPieces { color = PieceColor_Black }
+				if info.piece != planePieces[(plane - Plane_WhitePieces) % len(planePieces)] || info.color != color { count = -1 }
+			}
+			if (info.piece == Piece_Empty && count == 0) || (info.piece != Piece_Empty && count == 1) { continue }
+
+			return fmt.Errorf("wrong piece planes at %v in position %d %s", pos, i, ToFEN(p))
+		}
+	}
+	return nil
+}
+
+// verifyHash checks that positions with the same canonical FEN, and only those, have the same Zobrist hash and
+// the same Polyglot key, and that both survive a FEN round trip
+func verifyHash(positions []Position) error {
+	for _, hashFunc := range []func(Position) uint64{ ZobristHash, PolyglotKey } {
+		fens := map[uint64]string {}
+		for i, p := range positions {
+			hash, fen := hashFunc(p), CanonicalFEN(p)
+			parsed, err := ParseFEN(ToFEN(p))
+			if err == nil && hashFunc(parsed) == hash && CanonicalFEN(parsed) == fen {
+				if other, ok := fens[hash]; !ok || other == fen {
+					fens[hash] = fen
+					continue
+				}
+			}
+
+			return fmt.Errorf("hash %016x mismatch in position %d %s canonical %s previously %s %v", hash, i, ToFEN(p), fen,
+				fens[hash], err)
+		}
+	}
+	return nil
+}
+
+// verifyMoves checks that the structured moves of GenerateMoves describe the boards they lead to: the moved piece
+// ends on the to square, captures remove an enemy piece, and the coordinate notation finds the same move again
+func verifyMoves(positions []Position) error {
+	for i, p := range positions {
+		for _, m := range GenerateMoves(p) {
+			after := GetBoardAt(m.Board(), m.To())
+			moved := m.Piece()
+			if m.Promotion() != Piece_Empty { moved = m.Promotion() }
+			captured := GetBoardAt(p.board, m.To()).piece
+			if m.Is(MoveFlag_EnPassant) { captured = GetBoardAt(p.board, Square{ m.To().x, m.From().y }).piece }
+			found, ok := ParseMove(p, m.String())
+
+			if GetBoardAt(p.board, m.From()).piece == m.Piece() && after.piece == moved && after.color == p.sideToMove &&
+				captured == m.Captured() && m.Is(MoveFlag_Capture) == (captured != Piece_Empty) &&
+				ok && found == m.Board() && m == moveSquares(p, m.Board()) {
+				continue
+			}
+			return fmt.Errorf("wrong move %v in position %d %s", m, i, ToFEN(p))
+		}
+	}
+	return nil
+}
+
+// verifyMakeMove checks that MakeMove leaves every position as Play does, and that UnmakeMove restores it
+func verifyMakeMove(positions []Position) error {
+	for i, p := range positions {
+		for _, m := range GenerateMoves(p) {
+			made := p
+			undo := made.MakeMove(m)
+			played := made
+			made.UnmakeMove(undo)
+			if played == p.Play(m.board) && made == p { continue }
+
+			return fmt.Errorf("wrong make or unmake of %v in position %d %s", m, i, ToFEN(p))
+		}
+	}
+	return nil
+}
+
+// verification is an internal consistency check, which returns the first inconsistency found in the positions
+type verification struct {
+	name string
+	check func([]Position) error
+}
+
+// verifications are the internal consistency checks that Verify runs, by name; the ones of the search are added
+// by verify_search.go
+var verifications = []verification{
+	{ "quickmode", verifyQuickMode },
+	{ "captures", verifyCaptures },
+	{ "checks", verifyChecks },
+	{ "evasions", verifyEvasions },
+	{ "legality", verifyLegality },
+	{ "unique", verifyUnique },
+	{ "fen", verifyFEN },
+	{ "planes", verifyPlanes },
+	{ "hash", verifyHash },
+	{ "moves", verifyMoves },
+	{ "makemove", verifyMakeMove },
+}
+
+// VerificationNames returns the names of the checks that Verify can run
+func VerificationNames() []string {
+	names := []string{}
+	for _, v := range verifications {
+		names = append(names, v.name)
+	}
+	return names
+}
+
+// Verify runs the internal consistency check with the name given over positions, and returns the first
+// inconsistency it finds, described with the position it was found in
+func Verify(name string, positions []Position) error {
+	for _, v := range verifications {
+		if v.name == name { return v.check(positions) }
+	}
+	return fmt.Errorf("unknown verification %q", name)
+}
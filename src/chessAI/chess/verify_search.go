@@ -0,0 +1,85 @@
+//go:build !rulesonly
+
+package chess
+
+import "errors"
+import "fmt"
+import "strings"
+import "unicode"
+
+func init() {
+	verifications = append(verifications, []verification{
+		{ "symmetry", verifySymmetry },
+		{ "determinism", verifyDeterminism },
+	}...)
+}
+
+// mirrorFEN flips a position vertically and swaps the colors of all the pieces, so it's the same position with
+// colors reversed
+func mirrorFEN(fen string) string {
+	fields := strings.Fields(fen)
+
+	ranks := strings.Split(fields[0], "/")
+	for i, j := 0, len(ranks) - 1; i < j; i, j = i + 1, j - 1 {
+		ranks[i], ranks[j] = ranks[j], ranks[i]
+	}
+	swapCase := func(s string) string {
+		return strings.Map(func(r rune) rune {
+			if unicode.IsUpper(r) { return unicode.ToLower(r) }
+			return unicode.ToUpper(r)
+		}, s)
+	}
+	fields[0] = swapCase(strings.Join(ranks, "/"))
+
+	fields[1] = map[string]string { "w" : "b", "b" : "w" }[fields[1]]
+
+	if fields[2] != "-" {
+		castling := ""
+		for _, right := range "KQkq" {
+			if strings.ContainsRune(swapCase(fields[2]), right) { castling += string(right) }
+		}
+		fields[2] = castling
+	}
+
+	if fields[3] != "-" {
+		fields[3] = fields[3][:1] + map[byte]string { '3' : "6", '6' : "3" }[fields[3][1]]
+	}
+
+	return strings.Join(fields, " ")
+}
+
+// verifySymmetry checks that the evaluation gives the same score to a position and to its mirror. Scores are from
+// the point of view of the side to move, so they must be equal, not negated.
+func verifySymmetry(positions []Position) error {
+	for i, p := range positions {
+		fen := ToFEN(p)
+		mirrored, err := ParseFEN(mirrorFEN(fen))
+		if err != nil || mirrorFEN(ToFEN(mirrored)) != fen { return fmt.Errorf("can't mirror position %d %s %v", i, fen, err) }
+
+		score, mirroredScore := EvaluateBoard(p), EvaluateBoard(mirrored)
+		if score == mirroredScore { continue }
+
+		return fmt.Errorf("evaluation asymmetry in position %d %s score %d mirrored score %d", i, fen, score, mirroredScore)
+	}
+	return nil
+}
+
+// verifyDeterminism checks that searching the same position twice gives the same move, score and node count
+func verifyDeterminism(positions []Position) error {
+	options := DefaultSearchOptions()
+	options.depth = 2
+
+	for i, p := range positions {
+		if len(LegalMoves(p)) == 0 { continue }
+
+		first, second := SearchBestMove(p, options), SearchBestMove(p, options)
+		if first.bestMove == second.bestMove && first.score == second.score && first.nodes == second.nodes { continue }
+
+		message := fmt.Sprint("nondeterministic search in position ", i, " ", ToFEN(p))
+		for _, result := range []SearchResult{ first, second } {
+			message += fmt.Sprint("\n  ", DescribeMove(p, result.bestMove), " score ", result.score, " nodes ", result.nodes)
+		}
+		return errors.New(message)
+	}
+	return nil
+}
@@ -0,0 +1,15 @@
+//go:build !rulesonly
+
+package chess
+
+import "math/rand"
+import "testing"
+
+func TestEvaluationSymmetry(t *testing.T) {
+	if err := verifySymmetry(testPositions(t, 200)); err != nil { t.Fatal(err) }
+}
+
+func TestSearchDeterminism(t *testing.T) {
+	if testing.Short() { t.Skip("searches every position twice") }
+	if err := verifyDeterminism(RandomPositions(rand.New(rand.NewSource(1)), 8)); err != nil { t.Fatal(err) }
+}
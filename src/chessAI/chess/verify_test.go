@@ -0,0 +1,50 @@
+package chess
+
+import "math/rand"
+import "testing"
+
+// testPositions returns the built-in positions followed by count random ones, as the verify command makes them
+func testPositions(t *testing.T, count int) []Position {
+	positions := []Position{}
+	for _, name := range NamedPositionNames() {
+		position, err := ParseFEN(namedPositions[name].fen)
+		if err != nil { t.Fatal(name, err) }
+		positions = append(positions, position)
+	}
+	return append(positions, RandomPositions(rand.New(rand.NewSource(1)), count)...)
+}
+
+// the pawn moves that used to be generated twice: promotions, by pushing and capturing on both sides, and en
+// passant captures, for both colors
+var pawnMoveTests = []struct {
+	fen string
+	promotions, enPassant int
+}{
+	{ "1r1r4/2P5/8/8/8/8/8/k3K3 w - - 0 1", 12, 0 },
+	{ "k3K3/8/8/8/8/8/2p5/1R1R4 b - - 0 1", 12, 0 },
+	{ "4k3/8/8/2pPp3/8/8/8/4K3 w - c6 0 1", 0, 1 },
+	{ "4k3/8/8/8/3pPp2/8/8/4K3 b - e3 0 1", 0, 2 },
+	{ "4k3/8/8/8/1pP5/8/p7/1N2K3 b - c3 0 1", 8, 1 },
+}
+
+func TestUniqueMoves(t *testing.T) {
+	if err := verifyUnique(testPositions(t, 200)); err != nil { t.Fatal(err) }
+}
+
+func TestUniquePawnMoves(t *testing.T) {
+	for _, test := range pawnMoveTests {
+		position, err := ParseFEN(test.fen)
+		if err != nil { t.Fatal(test.fen, err) }
+		if err := verifyUnique([]Position{ position }); err != nil { t.Error(err) }
+
+		promotions, enPassant := 0, 0
+		for _, m := range GenerateMoves(position) {
+			if m.Is(MoveFlag_Promotion) { promotions ++ }
+			if m.Is(MoveFlag_EnPassant) { enPassant ++ }
+		}
+		if promotions != test.promotions || enPassant != test.enPassant {
+			t.Errorf("%s: %d promotions and %d en passant captures, want %d and %d", test.fen, promotions, enPassant,
+				test.promotions, test.enPassant)
+		}
+	}
+}
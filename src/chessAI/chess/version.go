@@ -0,0 +1,19 @@
+package chess
+
+// build metadata; release builds set these with
+//   go build -ldflags "-X chessAI/chess.version=1.0 -X chessAI/chess.commit=$(git rev-parse --short HEAD)" chessAI/cmd/chessai
+var version = "dev"
+var commit = ""
+var author = "hmoraldo"
+
+// EngineName identifies this build of the engine, e.g. in PGN tags for the games it plays
+func EngineName() string {
+	name := "chessAI " + version
+	if commit != "" { name += " (" + commit + ")" }
+	return name
+}
+
+// Author is who wrote the engine, for the version output
+func Author() string {
+	return author
+}
@@ -0,0 +1,196 @@
+package cli
+
+import "flag"
+import "fmt"
+import "strconv"
+import "strings"
+
+import "chessAI/chess"
+
+// loadPosition returns the position selected with --pos (a name from the library) or --fen; study relaxes the
+// validation of the FEN, as in ParseStudyFEN
+func loadPosition(posName, fen string, study bool) (position chess.Position, err error) {
+	if posName != "" {
+		named, ok := chess.GetNamedPosition(posName)
+		if !ok {
+			err = fmt.Errorf("unknown position %q, use --list to see the available ones", posName)
+			return
+		}
+		fen = named.FEN()
+	}
+	if fen == "" { return namedPosition("start"), nil }
+
+	if study { return chess.ParseStudyFEN(fen) }
+	return chess.ParseFEN(fen)
+}
+
+// namedPosition returns a position of the built-in library, whose FENs are known to be valid
+func namedPosition(name string) chess.Position {
+	named, _ := chess.GetNamedPosition(name)
+	position, err := chess.ParseFEN(named.FEN())
+	if err != nil { panic(err) }
+	return position
+}
+
+// Analyze runs the analyze command, which searches for the best move in a given position
+func Analyze(args []string) error {
+	flags := flag.NewFlagSet("analyze", flag.ContinueOnError)
+	posName := flags.String("pos", "", "name of a built-in position to analyze")
+	fen := flags.String("fen", "", "position to analyze, in FEN")
+	list := flags.Bool("list", false, "list the built-in positions")
+	study := flags.Bool("study", false, "accept the --fen of composed studies, ignoring castling rights and en-passant squares that don't match the pieces")
+	options := chess.DefaultSearchOptions()
+	depth := flags.Int("depth", options.Depth(), "search depth")
+	memoryMB := flags.Int("memory", options.MemoryMB(), "maximum memory used by the search, in MB")
+	lowMemory := flags.Bool("low-memory", false, "use the low memory profile, overriding --memory")
+	moveTime := flags.Duration("movetime", 0, "stop searching after this time, 0 means no limit")
+	overhead := flags.Duration("overhead", options.MoveOverhead(), "part of --movetime kept aside for everything but the search")
+	candidates := flags.Int("candidates", 0, "list this many candidate moves, with their scores at each of --depths")
+	depthList := flags.String("depths", "1,2,3", "comma separated search depths used by --candidates")
+	checkpointFile := flags.String("checkpoint", "", "save the analysis to this file after each depth completed")
+	resume := flags.Bool("resume", false, "continue the analysis saved in --checkpoint, from the next depth")
+	enginePath := flags.String("engine", "", "external UCI engine whose score is blended with ours at the root")
+	engineDepth := flags.Int("engine-depth", 12, "search depth for --engine")
+	blendWeight := flags.Float64("blend", 0.5, "share of the --engine score in the blended score, from 0 to 1")
+	epdFile := flags.String("epd", "", "analyze every position of this EPD file, checking the bm and am moves of test suites")
+	epdOut := flags.String("epd-out", "", "with --epd, write the records to this file with the ce and pv found")
+	if err := parseFlags(flags, args); err != nil { return err }
+	if *lowMemory { *memoryMB = chess.LowMemoryMB() }
+	engine := chess.NewEngine()
+	engine.SetDepth(*depth)
+	engine.SetMemoryMB(*memoryMB)
+	engine.SetMoveTime(*moveTime)
+	engine.SetMoveOverhead(*overhead)
+
+	if *list {
+		for _, name := range chess.NamedPositionNames() {
+			named, _ := chess.GetNamedPosition(name)
+			fmt.Printf("%-15s %s\n", name, named.Description())
+		}
+		return nil
+	}
+
+	if *epdFile != "" {
+		if err := analyzeEPD(*epdFile, *epdOut, engine); err != nil { return fmt.Errorf("can't analyze EPD: %v", err) }
+		return nil
+	}
+
+	var checkpoint analysisCheckpoint
+	if *resume {
+		if *checkpointFile == "" { return usageError("--resume needs --checkpoint") }
+		var err error
+		checkpoint, err = loadCheckpoint(*checkpointFile)
+		if err != nil { return fmt.Errorf("can't resume: %v", err) }
+		*posName, *fen = "", checkpoint.FEN
+		engine.SetStartDepth(checkpoint.Depth + 1)
+		fmt.Println("Resuming from depth", checkpoint.Depth, "best line", strings.Join(checkpoint.PV, " "), "score", checkpoint.Score)
+	}
+
+	position, err := loadPosition(*posName, *fen, *study)
+	if err != nil { return err }
+	checkpoint.FEN = chess.ToFEN(position)
+
+	newDisplay().drawTurn(position)
+	fmt.Println("Material", chess.GetMaterialSignature(position.Board()))
+
+	if len(chess.LegalMoves(position)) == 0 {
+		fmt.Println("No moves available")
+		return nil
+	}
+
+	if *candidates > 0 {
+		depths, err := parseDepths(*depthList)
+		if err != nil { return err }
+		showCandidates(position, *candidates, depths)
+		return nil
+	}
+
+	var pv []string
+	iterationComplete := func(info chess.SearchInfo) {
+		pv = info.PV()
+		fmt.Println(info)
+		fmt.Println("Search stats", info.Stats())
+		if *checkpointFile == "" { return }
+		if err := saveCheckpoint(*checkpointFile, checkpoint.update(info)); err != nil {
+			fmt.Println("Can't save checkpoint:", err)
+		}
+	}
+	engine.SetObserver(searchObserverFuncs{ iterationComplete: iterationComplete })
+	result := engine.Search(position)
+	if *resume && result.Depth() == 0 {
+		// the checkpoint's line can be empty, or not a move of the position if the file was edited
+		move, ok := chess.Board{}, len(checkpoint.PV) > 0
+		if ok { move, ok = chess.ParseMove(position, checkpoint.PV[0]) }
+		if !ok {
+			fmt.Println("No new depth completed, and the checkpoint has no best move")
+			return nil
+		}
+		fmt.Println("No new depth completed, best move still", checkpoint.PV[0], chess.ToSAN(position, move), "score", checkpoint.Score, "depth", checkpoint.Depth)
+		return nil
+	}
+	used, budget := result.MemoryUsage()
+	fmt.Println("Best move", chess.DescribeMove(position, result.BestMove()), chess.ToSAN(position, result.BestMove()), "score", result.Score(), "depth", result.Depth())
+	fmt.Println("Search memory used", used / 1024, "KB of", budget / 1024, "KB")
+	if motifs := chess.FindMotifs(position, chess.LineMoves(position, pv)); len(motifs) > 0 { fmt.Println("Motifs", chess.DescribeMotifs(motifs)) }
+
+	if *enginePath != "" {
+		if *blendWeight < 0 || *blendWeight > 1 { return usageError("--blend must be between 0 and 1") }
+		external, err := chess.StartExternalEngine(*enginePath)
+		if err == nil {
+			var score int
+			score, err = external.Evaluate(position, *engineDepth)
+			external.Close()
+			if err == nil {
+				fmt.Println("External engine score", score, "blended score", blendScores(result.Score(), score, *blendWeight))
+			}
+		}
+		if err != nil { return fmt.Errorf("can't use external engine: %v", err) }
+	}
+	return nil
+}
+
+// blendScores mixes the score of the internal search with an external one; weight is the share of the external
+// score, from 0 to 1
+func blendScores(internal, external int, weight float64) int {
+	return int(float64(internal) * (1 - weight) + float64(external) * weight)
+}
+
+func parseDepths(list string) ([]int, error) {
+	depths := []int{}
+	for _, field := range strings.Split(list, ",") {
+		depth, err := strconv.Atoi(strings.TrimSpace(field))
+		if err != nil || depth < 1 { return nil, fmt.Errorf("invalid depth %q", field) }
+		depths = append(depths, depth)
+	}
+	return depths, nil
+}
+
+// showCandidates prints the best moves side by side at several depths, so it's easy to see which moves only look
+// good at low depths. Moves are sorted by their score at the last depth.
+func showCandidates(position chess.Position, count int, depths []int) {
+	scores := map[chess.Board][]int {}
+	var ranking []chess.BoardScore
+	table := chess.NewTranspositionTable(chess.DefaultSearchOptions().MemoryMB())
+
+	for _, depth := range depths {
+		ranking = chess.ScoreMoves(position, depth, table)
+		for _, bs := range ranking {
+			scores[bs.Board()] = append(scores[bs.Board()], bs.Score())
+		}
+	}
+
+	fmt.Printf("%-8s", "move")
+	for _, depth := range depths {
+		fmt.Printf(" %9s", fmt.Sprint("depth ", depth))
+	}
+	fmt.Println("")
+
+	for i, bs := range ranking {
+		if i == count { break }
+		fmt.Printf("%-8s", chess.DescribeMove(position, bs.Board()))
+		for _, score := range scores[bs.Board()] {
+			fmt.Printf(" %9d", score)
+		}
+		fmt.Println("")
+	}
+}
@@ -0,0 +1,128 @@
+package cli
+
+import "encoding/json"
+import "flag"
+import "fmt"
+import "os"
+
+import "chessAI/chess"
+
+// benchEntry is the result of searching one of the benchmark positions
+type benchEntry struct {
+	Name string `json:"name"`
+	FEN string `json:"fen"`
+	Depth int `json:"depth"`
+	Nodes int `json:"nodes"`
+	TimeMs int64 `json:"time_ms"`
+	NPS int `json:"nps"`
+	BestMove string `json:"best_move"`
+	Score int `json:"score"`
+}
+
+// benchReport is what the bench command saves, for bench-compare
+type benchReport struct {
+	Engine string `json:"engine"`
+	Entries []benchEntry `json:"entries"`
+}
+
+// Bench runs the bench command, which searches every built-in position to a fixed depth
+func Bench(args []string) error {
+	flags := flag.NewFlagSet("bench", flag.ContinueOnError)
+	depth := flags.Int("depth", 2, "search depth")
+	memoryMB := flags.Int("memory", chess.DefaultSearchOptions().MemoryMB(), "maximum memory used by the search, in MB")
+	output := flags.String("out", "", "save the results to this file, as JSON, to compare them with bench-compare")
+	if err := parseFlags(flags, args); err != nil { return err }
+
+	engine := chess.NewEngine()
+	engine.SetDepth(*depth)
+	engine.SetMemoryMB(*memoryMB)
+	var info chess.SearchInfo
+	engine.SetObserver(searchObserverFuncs{ iterationComplete: func(i chess.SearchInfo) { info = i } })
+
+	report := benchReport{ Engine: chess.EngineName() }
+	var totalNodes int
+	var totalTimeMs int64
+	for _, name := range chess.NamedPositionNames() {
+		position := namedPosition(name)
+		if len(chess.LegalMoves(position)) == 0 { continue }
+
+		// every position is searched from scratch, so the results don't depend on the ones searched before
+		engine.NewGame()
+		result := engine.Search(position)
+
+		entry := benchEntry{ name, chess.ToFEN(position), result.Depth(), result.Nodes(), info.Elapsed().Milliseconds(),
+			info.NodesPerSecond(), chess.DescribeMove(position, result.BestMove()), result.Score() }
+		fmt.Printf("%-15s nodes %8d nps %6d time %6dms move %-6s score %d\n",
+			name, entry.Nodes, entry.NPS, entry.TimeMs, entry.BestMove, entry.Score)
+		report.Entries = append(report.Entries, entry)
+		totalNodes += entry.Nodes
+		totalTimeMs += entry.TimeMs
+	}
+	fmt.Println("total: nodes", totalNodes, "time", totalTimeMs, "ms")
+
+	if *output != "" {
+		data, err := json.MarshalIndent(report, "", "  ")
+		if err == nil { err = os.WriteFile(*output, data, 0644) }
+		if err != nil { return fmt.Errorf("can't save results: %v", err) }
+	}
+	return nil
+}
+
+func loadBenchReport(fileName string) (report benchReport, err error) {
+	data, err := os.ReadFile(fileName)
+	if err != nil { return }
+	err = json.Unmarshal(data, &report)
+	return
+}
+
+// percentChange formats the change from old to new
+func percentChange(old, new int) string {
+	if old == 0 { return "-" }
+	return fmt.Sprintf("%+.1f%%", float64(new - old) * 100 / float64(old))
+}
+
+// BenchCompare runs the bench-compare command, which shows the differences between two bench results, as saved
+// by bench --out: usually the same benchmark before and after a change
+func BenchCompare(args []string) error {
+	if len(args) != 2 { return usageError("usage: bench-compare old.json new.json") }
+	reports := [2]benchReport{}
+	for i, fileName := range args {
+		var err error
+		if reports[i], err = loadBenchReport(fileName); err != nil { return fmt.Errorf("can't read bench results: %v", err) }
+	}
+	old, new := reports[0], reports[1]
+	fmt.Println("old:", old.Engine, "new:", new.Engine)
+
+	newEntries := map[string]benchEntry {}
+	for _, entry := range new.Entries {
+		newEntries[entry.Name] = entry
+	}
+
+	var oldNodes, newNodes, changedMoves int
+	for _, before := range old.Entries {
+		after, ok := newEntries[before.Name]
+		if !ok {
+			fmt.Printf("%-15s only in the old results\n", before.Name)
+			continue
+		}
+		delete(newEntries, before.Name)
+
+		move := before.BestMove
+		if after.BestMove != before.BestMove || after.Score != before.Score {
+			move = fmt.Sprint(before.BestMove, " (", before.Score, ") -> ", after.BestMove, " (", after.Score, ")")
+			changedMoves ++
+		}
+		if after.FEN != before.FEN || after.Depth != before.Depth { move += ", different position or depth" }
+		fmt.Printf("%-15s nodes %8d -> %8d %7s  nps %6d -> %6d %7s  move %s\n", before.Name,
+			before.Nodes, after.Nodes, percentChange(before.Nodes, after.Nodes),
+			before.NPS, after.NPS, percentChange(before.NPS, after.NPS), move)
+		oldNodes += before.Nodes
+		newNodes += after.Nodes
+	}
+	for _, after := range new.Entries {
+		if _, ok := newEntries[after.Name]; ok { fmt.Printf("%-15s only in the new results\n", after.Name) }
+	}
+	fmt.Println("total: nodes", oldNodes, "->", newNodes, percentChange(oldNodes, newNodes), "and", changedMoves,
+		"positions with a different move or score")
+	return nil
+}
@@ -0,0 +1,43 @@
+package cli
+
+import "encoding/json"
+import "os"
+import "time"
+
+import "chessAI/chess"
+
+// analysisCheckpoint is saved by analyze after each depth completed, so a long analysis can be stopped and
+// resumed later from the next depth. The transposition table isn't saved, so a resumed search starts with an empty
+// one, and the first depth it searches takes longer than it would have.
+type analysisCheckpoint struct {
+	FEN string `json:"fen"`
+	Depth int `json:"depth"` // deepest search completed
+	Score int `json:"score"`
+	PV []string `json:"pv"` // starting with the best move
+	Nodes int `json:"nodes"` // counting all the sessions
+	ElapsedMs int64 `json:"elapsed_ms"` // counting all the sessions
+}
+
+func loadCheckpoint(fileName string) (checkpoint analysisCheckpoint, err error) {
+	data, err := os.ReadFile(fileName)
+	if err != nil { return }
+	err = json.Unmarshal(data, &checkpoint)
+	return
+}
+
+// saveCheckpoint writes to a temporary file first, so stopping analyze in the middle of a write doesn't lose the
+// previous checkpoint
+func saveCheckpoint(fileName string, checkpoint analysisCheckpoint) error {
+	data, err := json.MarshalIndent(checkpoint, "", "  ")
+	if err != nil { return err }
+
+	tmpFileName := fileName + ".tmp"
+	if err := os.WriteFile(tmpFileName, data, 0644); err != nil { return err }
+	return os.Rename(tmpFileName, fileName)
+}
+
+// update records a new depth completed in the session that started with the checkpoint in previous
+func (previous analysisCheckpoint) update(info chess.SearchInfo) analysisCheckpoint {
+	elapsed := time.Duration(previous.ElapsedMs) * time.Millisecond + info.Elapsed()
+	return analysisCheckpoint{ previous.FEN, info.Depth(), info.Score(), info.PV(), previous.Nodes + info.Nodes(), elapsed.Milliseconds() }
+}
@@ -0,0 +1,186 @@
+// Package cli is the chessAI program's front end, built on package chessAI/chess: the interactive game played in
+// the terminal, with RunGame, and the commands that test and tune the engine, like Analyze, Bench and Perft
+package cli
+
+import "encoding/json"
+import "errors"
+import "flag"
+import "fmt"
+import "os"
+import "time"
+
+import "chessAI/chess"
+
+// UsageError tells that a command was given wrong arguments, rather than failing to do its work: the chessAI
+// program exits with status 2 for it, as the flag package does
+type UsageError struct {
+	err error
+	reported bool // already printed, with the usage of the command
+}
+
+func (e UsageError) Error() string {
+	return e.err.Error()
+}
+
+func (e UsageError) Unwrap() error {
+	return e.err
+}
+
+// Reported tells whether the error was already printed along with the usage of the command, as the flag package
+// does with the errors it finds, so it shouldn't be printed again
+func (e UsageError) Reported() bool {
+	return e.reported
+}
+
+func usageError(message string) error {
+	return UsageError{ errors.New(message), false }
+}
+
+// parseFlags parses the arguments of a command; the flag set prints what's wrong with them, and the usage
+func parseFlags(flags *flag.FlagSet, args []string) error {
+	if err := flags.Parse(args); err != nil { return UsageError{ err, true } }
+	return nil
+}
+
+// badUsage prints the usage of a command whose arguments are wrong
+func badUsage(flags *flag.FlagSet) error {
+	flags.Usage()
+	return UsageError{ errors.New("wrong arguments"), true }
+}
+
+// GameConfig holds the settings of an interactive game, which the chessAI program takes from its flags
+type GameConfig struct {
+	Players int // 0 to watch the computer play itself, 1 to play against it, 2 for two players
+	Bot string // the computer opponent, one of BotNames
+	Depth *int // maximum search depth, nil for the bot's
+	Swindle *bool // when losing, prefer tricky moves over objectively best ones; nil for the bot's
+	EasyMove *bool // with a time limit, play obvious moves without using the time available; nil for the bot's
+	MemoryMB int // maximum memory used by the search
+	LowMemory bool // use the low memory profile, overriding MemoryMB
+	MoveTime time.Duration // time the computer can spend on each move, 0 means no limit; the clock overrides it
+	MoveOverhead time.Duration // time kept aside on each move for everything but the search
+	Time, Increment time.Duration // on each player's clock, and added after each move; no clock if Time is 0
+	WhiteTime, BlackTime time.Duration // override Time, to give time odds
+	ResultFile string // where the result of the game is written, as JSON; nowhere if empty
+	PGNFile string // where the game is written, in PGN; nowhere if empty
+	Event string // event name in the PGN tags
+	AdjournFile string // where adjourned games are saved
+	Resume bool // resume the game saved in AdjournFile
+	AutoQueen bool // promote pawns to queens without asking
+	Bell bool // ring the terminal bell on captures and checks
+	Rules string // how draws are applied, one of the rules profiles: casual or fide
+	Orientation string // side drawn at the bottom of the board: white, black, or flip to follow the side to move
+	Delay time.Duration // with Players 0, pause this long after each move
+	Render string // how to draw the board: auto, unicode or ascii
+	Color string // use colors in the board: auto, always or never
+}
+
+// DefaultGameConfig returns the settings of a game against the default bot, with no clock
+func DefaultGameConfig() GameConfig {
+	options := chess.DefaultSearchOptions()
+	return GameConfig{ Players: 1, Bot: "default", MemoryMB: options.MemoryMB(), MoveOverhead: options.MoveOverhead(),
+		Event: "Casual game", AdjournFile: "adjourned.json", Rules: chess.DefaultRules().String(), Orientation: "white",
+		Render: "auto", Color: "auto" }
+}
+
+// RunGame plays the game the settings describe, or resumes it, and then adjourns it or writes its result. Wrong
+// settings are a UsageError.
+func RunGame(config GameConfig) error {
+	if config.Players < 0 || config.Players > 2 { return usageError("The number of players must be 0, 1 or 2") }
+	display := newDisplay()
+	var ok bool
+	if display.orientation, ok = ParseOrientation(config.Orientation); !ok {
+		return usageError("Unknown orientation " + config.Orientation)
+	}
+	if config.Render != "auto" {
+		mode, ok := ParseRenderMode(config.Render)
+		if !ok { return usageError("Unknown render mode " + config.Render) }
+		display.terminal.mode = mode
+	}
+	switch config.Color {
+	case "always":
+		display.terminal.color = true
+	case "never":
+		display.terminal.color = false
+	case "auto":
+	default:
+		return usageError("Unknown color option " + config.Color)
+	}
+
+	// the search settings given override the bot's
+	engine := chess.NewEngine()
+	engine.SetMoveTime(config.MoveTime)
+	engine.SetMoveOverhead(config.MoveOverhead)
+	if !engine.SetBot(config.Bot) {
+		return usageError("Unknown bot " + config.Bot + ", the bots are:\n" + chess.DescribeBots())
+	}
+	if config.Depth != nil { engine.SetDepth(*config.Depth) }
+	if config.Swindle != nil { engine.SetSwindle(*config.Swindle) }
+	if config.EasyMove != nil { engine.SetEasyMove(*config.EasyMove) }
+	memoryMB := config.MemoryMB
+	if config.LowMemory { memoryMB = chess.LowMemoryMB() }
+	engine.SetMemoryMB(memoryMB)
+
+	rules, known := chess.GetRules(config.Rules)
+	if !known { return usageError("Unknown rules " + config.Rules) }
+	if config.AutoQueen && !rules.AutoQueen() {
+		return usageError("-auto-queen isn't allowed by the " + rules.String() + " rules")
+	}
+
+	var clock *chess.Clock
+	whiteTime, blackTime := config.WhiteTime, config.BlackTime
+	if whiteTime == 0 { whiteTime = config.Time }
+	if blackTime == 0 { blackTime = config.Time }
+	if whiteTime > 0 || blackTime > 0 {
+		if whiteTime <= 0 || blackTime <= 0 {
+			return usageError("Both players need time on their clock, use -time or both -white-time and -black-time")
+		}
+		clock = chess.NewOddsClock(whiteTime, blackTime, config.Increment)
+	}
+
+	players, position, plies := config.Players, chess.InitialPosition(false), 0
+	if config.Resume {
+		var err error
+		players, position, plies, clock, err = chess.ResumeGame(config.AdjournFile)
+		if err != nil { return fmt.Errorf("Can't resume game: %v", err) }
+	}
+	// swindling only makes sense against a human
+	if players != 1 { engine.SetSwindle(false) }
+
+	// the computer plays white unless both players are human
+	white, black := chess.EngineName(), chess.EngineName()
+	if players > 0 { black = "Player" }
+	if players > 1 { white = "Player" }
+	game := chess.ContinueGame(position, plies, white, black)
+	game.SetRules(rules)
+	game.SetClock(clock)
+	computerTime := chess.NewPhaseTimes()
+	game.SetComputerTime(computerTime)
+	if config.Bell { game.Subscribe(bellObserver) }
+
+	s := session{ console: newConsole(os.Stdin, display), players: players, autoQueen: config.AutoQueen,
+		delay: config.Delay, engine: engine, game: game, computerTime: computerTime }
+	result := s.play()
+
+	if result.Termination() == chess.Termination_Adjourned {
+		if err := chess.AdjournGame(config.AdjournFile, players, result, clock); err != nil {
+			return fmt.Errorf("Can't adjourn game: %v", err)
+		}
+		fmt.Println("Game adjourned, resume it with -resume -adjourn-file", config.AdjournFile)
+		return nil
+	}
+	fmt.Println("Game over, result:", result.Score(), result)
+	if players < 2 { fmt.Println("Computer time:", computerTime) }
+
+	if config.ResultFile != "" {
+		data, err := json.MarshalIndent(result, "", "  ")
+		if err == nil { err = os.WriteFile(config.ResultFile, data, 0644) }
+		if err != nil { return fmt.Errorf("Can't write result: %v", err) }
+	}
+	if config.PGNFile != "" {
+		if err := os.WriteFile(config.PGNFile, []byte(result.PGN(config.Event, time.Now())), 0644); err != nil {
+			return fmt.Errorf("Can't write PGN: %v", err)
+		}
+	}
+	return nil
+}
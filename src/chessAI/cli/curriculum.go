@@ -0,0 +1,185 @@
+package cli
+
+import "errors"
+import "flag"
+import "fmt"
+import "math/rand"
+import "strings"
+
+import "chessAI/chess"
+
+// curriculumMaterial is the material of an endgame, written like "KRK" or "KQKR": white's pieces first, starting
+// with its king, then black's. White is the side expected to win.
+type curriculumMaterial struct {
+	name string
+	white, black []chess.Piece
+}
+
+// curriculumPieces are the pieces a material set can have
+var curriculumPieces = []chess.Piece{
+	chess.Piece_King, chess.Piece_Queen, chess.Piece_Rock, chess.Piece_Bishop, chess.Piece_Knight, chess.Piece_Pawn,
+}
+
+// pieceLetter writes piece as its FEN letter for color
+func pieceLetter(piece chess.Piece, color chess.PieceColor) string {
+	return chess.NewPieceInfo(piece, chess.PieceStatus_Default, color).String()
+}
+
+// parseCurriculumMaterial reads a material set like "KQKR"
+func parseCurriculumMaterial(name string) (material curriculumMaterial, err error) {
+	name = strings.ToUpper(name)
+	material.name = name
+	second := strings.LastIndex(name, "K")
+	if !strings.HasPrefix(name, "K") || second <= 0 || strings.Count(name, "K") != 2 {
+		err = fmt.Errorf("material %q must be like KRK, with each side's pieces starting with its king", name)
+		return
+	}
+	for i, c := range name {
+		piece := chess.Piece_Empty
+		for _, p := range curriculumPieces {
+			if pieceLetter(p, chess.PieceColor_White) == string(c) { piece = p }
+		}
+		if piece == chess.Piece_Empty {
+			err = fmt.Errorf("unknown piece %q in material %q", c, name)
+			return
+		}
+		if i < second {
+			material.white = append(material.white, piece)
+		} else {
+			material.black = append(material.black, piece)
+		}
+	}
+	return
+}
+
+const randomPositionTries = 1000
+
+// randomPosition places the pieces of material on random squares, pawns outside the first and last ranks, with
+// the given side to move. Positions that aren't legal, or where the game is already over, are discarded; ok is false
+// if no position is found after randomPositionTries, as with material that can't mate.
+func (m curriculumMaterial) randomPosition(rnd *rand.Rand, sideToMove chess.PieceColor) (position chess.Position, ok bool) {
+	turn := "w"
+	if sideToMove == chess.PieceColor_Black { turn = "b" }
+
+	for try := 0; try < randomPositionTries; try ++ {
+		var squares [8][8]string
+		place := func(piece chess.Piece, letter string) {
+			for {
+				x, y := rnd.Intn(8), rnd.Intn(8)
+				if squares[y][x] != "" || (piece == chess.Piece_Pawn && (y == 0 || y == 7)) { continue }
+				squares[y][x] = letter
+				return
+			}
+		}
+		for _, piece := range m.white {
+			place(piece, pieceLetter(piece, chess.PieceColor_White))
+		}
+		for _, piece := range m.black {
+			place(piece, pieceLetter(piece, chess.PieceColor_Black))
+		}
+
+		ranks := []string{}
+		for _, rank := range squares {
+			text, empty := "", 0
+			for _, letter := range rank {
+				if letter == "" {
+					empty ++
+					continue
+				}
+				if empty > 0 { text += fmt.Sprint(empty) }
+				text, empty = text + letter, 0
+			}
+			if empty > 0 { text += fmt.Sprint(empty) }
+			ranks = append(ranks, text)
+		}
+
+		// ParseFEN rejects kings next to each other, or the side not to move in check
+		var err error
+		position, err = chess.ParseFEN(strings.Join(ranks, "/") + " " + turn + " - - 0 1")
+		if err != nil { continue }
+		if _, finished := chess.GetResult(position, 0); !finished { return position, true }
+	}
+	return
+}
+
+// curriculumStats counts the results of the games played with a material set, from white's point of view
+type curriculumStats struct {
+	games, won, drawn, lost int
+	wonPlies int // half moves played in the games won
+	unfinished int // games stopped after the maximum number of moves, counted as draws
+}
+
+func (s curriculumStats) String() string {
+	rate, length := 0.0, 0.0
+	if s.games > 0 { rate = float64(s.won) * 100 / float64(s.games) }
+	if s.won > 0 { length = float64(s.wonPlies) / float64(s.won) / 2 }
+	return fmt.Sprintf("games %d won %d (%.1f%%) drawn %d (%d unfinished) lost %d, %.1f moves per win",
+		s.games, s.won, rate, s.drawn, s.unfinished, s.lost, length)
+}
+
+// playCurriculumGame plays the computer against itself from position, without drawing anything, until the game
+// ends or maxPlies half moves are played. What engine found in earlier games is forgotten for the game.
+func playCurriculumGame(position chess.Position, engine *chess.Engine, maxPlies int) (result chess.Result, finished bool) {
+	engine.NewGame()
+	game := chess.NewGame(position, "", "")
+	for plies := 0; ; plies ++ {
+		if result = game.Result(); result.Finished() { return result, true }
+		if plies == maxPlies { return }
+		game.Play(engine.SearchGame(game).BestMove())
+	}
+}
+
+// Curriculum runs the curriculum command, which plays the computer against itself in random positions with the
+// material of some endgames, and reports how often the stronger side converts them: a direct measure of whether
+// endgame evaluation terms help in those endings
+func Curriculum(args []string) error {
+	flags := flag.NewFlagSet("curriculum", flag.ContinueOnError)
+	materials := flags.String("material", "KQK,KRK,KPK,KQKR", "comma separated material sets to play, white's pieces first")
+	games := flags.Int("games", 10, "games played with each material set, half of them with black to move")
+	maxMoves := flags.Int("max-moves", 100, "moves after which an unfinished game counts as a draw")
+	seed := flags.Int64("seed", 1, "seed for generating the starting positions")
+	depth := flags.Int("depth", 3, "search depth")
+	memoryMB := flags.Int("memory", chess.DefaultSearchOptions().MemoryMB(), "maximum memory used by the search, in MB")
+	if err := parseFlags(flags, args); err != nil { return err }
+
+	var sets []curriculumMaterial
+	for _, name := range strings.Split(*materials, ",") {
+		material, err := parseCurriculumMaterial(strings.TrimSpace(name))
+		if err == nil && len(material.white) + len(material.black) > 32 { err = errors.New("too many pieces in " + material.name) }
+		if err != nil { return UsageError{ err, false } }
+		sets = append(sets, material)
+	}
+
+	engine := chess.NewEngine()
+	engine.SetDepth(*depth)
+	engine.SetMemoryMB(*memoryMB)
+	engine.SetSwindle(false)
+	engine.SetEasyMove(false)
+	rnd := rand.New(rand.NewSource(*seed))
+	for _, material := range sets {
+		var stats curriculumStats
+		for i := 0; i < *games; i ++ {
+			position, ok := material.randomPosition(rnd, i % 2 == 0)
+			if !ok {
+				fmt.Println("No playable position found for", material.name)
+				break
+			}
+			result, finished := playCurriculumGame(position, engine, *maxMoves * 2)
+			stats.games ++
+			switch {
+			case !finished:
+				stats.drawn ++
+				stats.unfinished ++
+			case result.Draw():
+				stats.drawn ++
+			case result.Winner() == chess.PieceColor_White:
+				stats.won ++
+				stats.wonPlies += result.Plies()
+			default:
+				stats.lost ++
+			}
+		}
+		if stats.games > 0 { fmt.Printf("%-8s %v\n", material.name, stats) }
+	}
+	return nil
+}
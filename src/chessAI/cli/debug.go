@@ -0,0 +1,227 @@
+package cli
+
+import "fmt"
+import "os"
+import "strconv"
+import "strings"
+
+import "chessAI/chess"
+
+var debugHelp = `Commands:
+  position <fen>|<name>  set up a position, from FEN or the built-in library
+  study on|off           accept composed studies in position, ignoring castling and en passant that don't fit
+  board                  draw the position
+  fen                    print the position in FEN
+  hash                   print the Zobrist hash, the Polyglot key and the canonical FEN
+  epd [<record>]         print the position in EPD, or set it up from an EPD record and show its operations
+  moves                  list the legal moves
+  play <move>            play a move, like e2e4 or e7e8n
+  undo                   take back the last move played
+  eval                   static evaluation, from the side to move
+  material               material signature
+  see <move>             static exchange evaluation of a capture, like e4xd5
+  control <square>       attackers and defenders of a square, and who controls it
+  perft <depth>          count the positions reached at a depth
+  divide <depth>         perft for each move, to find where move generation goes wrong
+  search depth <depth>   search the position
+  ttprobe                show the scores stored by the last search for the moves available
+  help                   show this help
+  quit                   leave the console`
+
+// Debug runs the debug command, an interactive console with direct access to move generation, evaluation and search
+func Debug(args []string) error {
+	position := namedPosition("start")
+	history := []chess.Position{}
+	var table *chess.TranspositionTable
+	study := false
+	var legalMoves chess.MoveCache // of the position on display
+	console := newConsole(os.Stdin, newDisplay())
+
+	fmt.Println(debugHelp)
+	for {
+		fmt.Print("debug> ")
+		line, ok := console.readLine()
+		if !ok { return nil }
+
+		fields := strings.Fields(line)
+		if len(fields) == 0 { continue }
+		command, rest := fields[0], fields[1:]
+
+		switch command {
+		case "position":
+			newPosition, err := loadPosition("", strings.Join(rest, " "), study)
+			if len(rest) == 1 { newPosition, err = loadPosition(rest[0], "", study) }
+			if err != nil {
+				fmt.Println(err)
+				continue
+			}
+			position, history, table = newPosition, nil, nil
+			console.display.drawTurn(position)
+
+		case "study":
+			if len(rest) != 1 || (rest[0] != "on" && rest[0] != "off") {
+				fmt.Println("Usage: study on|off")
+				continue
+			}
+			study = rest[0] == "on"
+
+		case "board":
+			console.display.drawTurn(position)
+
+		case "fen":
+			fmt.Println(chess.ToFEN(position))
+
+		case "epd":
+			if len(rest) == 0 {
+				fmt.Println(chess.NewEPDRecord(position))
+				continue
+			}
+			record, err := chess.ParseEPD(strings.Join(rest, " "))
+			if err != nil {
+				fmt.Println(err)
+				continue
+			}
+			position, history, table = record.Position(), nil, nil
+			console.display.drawTurn(position)
+			for _, opcode := range record.Opcodes() {
+				operands, _ := record.Get(opcode)
+				fmt.Println(opcode, strings.Join(operands, " "))
+			}
+
+		case "hash":
+			fmt.Printf("%016x %016x %s\n", chess.ZobristHash(position), chess.PolyglotKey(position), chess.CanonicalFEN(position))
+
+		case "moves":
+			descriptions := []string{}
+			for _, m := range legalMoves.LegalMoves(position) {
+				descriptions = append(descriptions, chess.DescribeMove(position, m))
+			}
+			fmt.Println(len(descriptions), "moves:", strings.Join(descriptions, " "))
+
+		case "play":
+			if len(rest) != 1 {
+				fmt.Println("Usage: play <move>")
+				continue
+			}
+			move, ok := legalMoves.ParseMove(position, rest[0])
+			if !ok {
+				fmt.Println("Not a legal move:", rest[0])
+				continue
+			}
+			history = append(history, position)
+			position = position.Play(move)
+			console.display.drawTurn(position)
+
+		case "undo":
+			if len(history) == 0 {
+				fmt.Println("Nothing to undo")
+				continue
+			}
+			position, history = history[len(history) - 1], history[:len(history) - 1]
+			console.display.drawTurn(position)
+
+		case "eval":
+			fmt.Println("Evaluation", chess.EvaluateBoard(position))
+
+		case "material":
+			signature := chess.GetMaterialSignature(position.Board())
+			fmt.Println("Material", signature, "insufficient", chess.InsufficientMaterial(position.Board()))
+
+		case "see":
+			if len(rest) != 1 {
+				fmt.Println("Usage: see <move>")
+				continue
+			}
+			move, ok := legalMoves.ParseMove(position, rest[0])
+			if !ok {
+				fmt.Println("Not a legal move:", rest[0])
+				continue
+			}
+			description := chess.DescribeMove(position, move)
+			from, _ := chess.SquareFromString(description[:2])
+			to, _ := chess.SquareFromString(description[2:4])
+			if chess.GetBoardAt(position.Board(), to).Piece() == chess.Piece_Empty {
+				fmt.Println("Not a capture:", description)
+				continue
+			}
+			fmt.Println("Static exchange", chess.StaticExchange(position.Board(), from, to))
+
+		case "control":
+			if len(rest) != 1 {
+				fmt.Println("Usage: control <square>")
+				continue
+			}
+			square, err := chess.SquareFromString(rest[0])
+			if err != nil {
+				fmt.Println(err)
+				continue
+			}
+			attackers, defenders := chess.SquareControl(position, square)
+			fmt.Println("Attackers", chess.DescribePieces(attackers), "defenders", chess.DescribePieces(defenders), "net control", chess.NetControl(position, square))
+
+		case "perft":
+			depth, err := strconv.Atoi(strings.Join(rest, ""))
+			if err != nil || depth < 0 {
+				fmt.Println("Usage: perft <depth>")
+				continue
+			}
+			fmt.Println("Perft", depth, ":", chess.Perft(position, depth))
+
+		case "divide":
+			depth, err := strconv.Atoi(strings.Join(rest, ""))
+			if err != nil || depth < 1 {
+				fmt.Println("Usage: divide <depth>")
+				continue
+			}
+			printDivide(position, depth)
+
+		case "search":
+			if len(rest) != 2 || rest[0] != "depth" {
+				fmt.Println("Usage: search depth <depth>")
+				continue
+			}
+			depth, err := strconv.Atoi(rest[1])
+			if err != nil || depth < 1 {
+				fmt.Println("Invalid depth", rest[1])
+				continue
+			}
+			if len(legalMoves.LegalMoves(position)) == 0 {
+				fmt.Println("No moves available")
+				continue
+			}
+			// every search starts with an empty table, which ttprobe shows
+			engine := chess.NewEngine()
+			engine.SetDepth(depth)
+			engine.SetObserver(searchObserverFuncs{ iterationComplete: func(info chess.SearchInfo) { fmt.Println(info) } })
+			result := engine.Search(position)
+			table = engine.Table()
+			fmt.Println("Best move", chess.DescribeMove(position, result.BestMove()), "score", result.Score(), "nodes", result.Nodes())
+
+		case "ttprobe":
+			if table == nil {
+				fmt.Println("No search to probe, use search first")
+				continue
+			}
+			fmt.Println("Table entries", table.Entries(), "using", table.MemoryUsage() / 1024, "KB")
+			for _, m := range legalMoves.LegalMoves(position) {
+				// the table has the scores of the positions after the moves, from the opponent's point of view, so
+				// their lower and upper bounds swap too
+				if score, depth, bound, ok := table.Get(position.Play(m)); ok {
+					if bound != chess.Bound_Exact { bound = chess.Bound_Lower + chess.Bound_Upper - bound }
+					fmt.Printf("%-8s %6d depth %d %s\n", chess.DescribeMove(position, m), - score, depth, bound)
+				} else {
+					fmt.Printf("%-8s %6s\n", chess.DescribeMove(position, m), "-")
+				}
+			}
+
+		case "help":
+			fmt.Println(debugHelp)
+
+		case "quit", "exit":
+			return nil
+
+		default:
+			fmt.Println("Unknown command", command, "- type help to see the commands")
+		}
+	}
+}
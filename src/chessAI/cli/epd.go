@@ -0,0 +1,84 @@
+package cli
+
+import "fmt"
+import "os"
+import "strings"
+
+import "chessAI/chess"
+
+// sanLine writes a line of moves in coordinate notation, played from position, in SAN
+func sanLine(position chess.Position, line []string) (sans []string) {
+	for _, move := range chess.LineMoves(position, line) {
+		sans = append(sans, chess.ToSAN(position, move))
+		position = position.Play(move)
+	}
+	return
+}
+
+// analyzeEPD searches every record of an EPD file with engine, like a test suite: a record is solved if the best
+// move found is one of its bm moves and none of its am moves. If outName isn't empty, the records are written there
+// with the ce and pv found, the tactical motifs of the pv as a c9 comment, and with the move found as bm when they
+// had neither bm nor am.
+func analyzeEPD(fileName, outName string, engine *chess.Engine) error {
+	data, err := os.ReadFile(fileName)
+	if err != nil { return err }
+
+	var out []string
+	solved, tests := 0, 0
+	for i, line := range strings.Split(string(data), "\n") {
+		if strings.TrimSpace(line) == "" { continue }
+		record, err := chess.ParseEPD(line)
+		if err != nil { return fmt.Errorf("line %d: %v", i + 1, err) }
+		best, err := record.Moves("bm")
+		if err != nil { return fmt.Errorf("line %d: %v", i + 1, err) }
+		avoid, err := record.Moves("am")
+		if err != nil { return fmt.Errorf("line %d: %v", i + 1, err) }
+
+		name := fmt.Sprint("line ", i + 1)
+		if id, ok := record.Get("id"); ok && len(id) > 0 { name = id[0] }
+		position := record.Position()
+		if len(chess.LegalMoves(position)) == 0 {
+			fmt.Println(name, "has no moves")
+			out = append(out, record.String())
+			continue
+		}
+
+		// each record is searched from scratch, as if it was the only one
+		engine.NewGame()
+		var pv []string
+		engine.SetObserver(searchObserverFuncs{ iterationComplete: func(info chess.SearchInfo) { pv = info.PV() } })
+		result := engine.Search(position)
+		san := chess.ToSAN(position, result.BestMove())
+
+		status := ""
+		if len(best) > 0 || len(avoid) > 0 {
+			ok := len(best) == 0
+			for _, move := range best {
+				if move == result.BestMove() { ok = true }
+			}
+			for _, move := range avoid {
+				if move == result.BestMove() { ok = false }
+			}
+			tests ++
+			status = "failed"
+			if ok {
+				solved ++
+				status = "solved"
+			}
+		} else {
+			record.Set("bm", san)
+		}
+		fmt.Println(name, san, "score", result.Score(), "depth", result.Depth(), status)
+
+		record.Set("ce", fmt.Sprint(chess.ToCentipawns(result.Score())))
+		record.Set("pv", sanLine(position, pv)...)
+		if motifs := chess.FindMotifs(position, chess.LineMoves(position, pv)); len(motifs) > 0 {
+			record.Set("c9", chess.DescribeMotifs(motifs))
+		}
+		out = append(out, record.String())
+	}
+	if tests > 0 { fmt.Println("Solved", solved, "of", tests) }
+
+	if outName == "" { return nil }
+	return os.WriteFile(outName, []byte(strings.Join(out, "\n") + "\n"), 0644)
+}
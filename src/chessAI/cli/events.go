@@ -0,0 +1,52 @@
+package cli
+
+import "fmt"
+
+import "chessAI/chess"
+
+// searchObserverFuncs is a chess.SearchObserver made of functions, any of which can be nil
+type searchObserverFuncs struct {
+	iterationComplete func(chess.SearchInfo)
+	bestMoveChange func(chess.SearchInfo)
+	finish func(chess.SearchResult)
+}
+
+func (o searchObserverFuncs) OnIterationComplete(info chess.SearchInfo) {
+	if o.iterationComplete != nil { o.iterationComplete(info) }
+}
+
+func (o searchObserverFuncs) OnBestMoveChange(info chess.SearchInfo) {
+	if o.bestMoveChange != nil { o.bestMoveChange(info) }
+}
+
+func (o searchObserverFuncs) OnFinish(result chess.SearchResult) {
+	if o.finish != nil { o.finish(result) }
+}
+
+// gameObserverFuncs is a chess.GameObserver made of functions, any of which can be nil
+type gameObserverFuncs struct {
+	move, capture, check func(chess.Position, chess.MoveInfo)
+	gameEnd func(chess.Result)
+}
+
+func (o gameObserverFuncs) OnMove(position chess.Position, move chess.MoveInfo) {
+	if o.move != nil { o.move(position, move) }
+}
+
+func (o gameObserverFuncs) OnCapture(position chess.Position, move chess.MoveInfo) {
+	if o.capture != nil { o.capture(position, move) }
+}
+
+func (o gameObserverFuncs) OnCheck(position chess.Position, move chess.MoveInfo) {
+	if o.check != nil { o.check(position, move) }
+}
+
+func (o gameObserverFuncs) OnGameEnd(result chess.Result) {
+	if o.gameEnd != nil { o.gameEnd(result) }
+}
+
+// bellObserver rings the terminal bell on captures and checks
+var bellObserver = gameObserverFuncs{
+	capture: func(chess.Position, chess.MoveInfo) { fmt.Print("\a") },
+	check: func(chess.Position, chess.MoveInfo) { fmt.Print("\a") },
+}
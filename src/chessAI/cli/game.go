@@ -0,0 +1,302 @@
+package cli
+
+import "bufio"
+import "fmt"
+import "io"
+import "strings"
+import "time"
+
+import "chessAI/chess"
+
+// console is an interactive terminal: the lines typed in, and the display the boards are drawn on
+type console struct {
+	input *bufio.Scanner
+	display display
+}
+
+func newConsole(input io.Reader, display display) console {
+	return console{ bufio.NewScanner(input), display }
+}
+
+// readLine reads a line from the input; ok is false once there's nothing left to read
+func (c console) readLine() (line string, ok bool) {
+	ok = c.input.Scan()
+	return strings.TrimSpace(c.input.Text()), ok
+}
+
+// session is an interactive game, played on a console with the settings of a GameConfig
+type session struct {
+	console
+	players int // 0 (computer - computer), 1 (computer - player) or 2 (player - player)
+	autoQueen bool // makes pawns promote to queens without asking, unless the move says otherwise
+	delay time.Duration // the pause after each move when the computer plays itself, so the game can be followed
+	engine *chess.Engine // for the computer's moves and the hints, which search the positions of the same game
+	game *chess.Game
+	computerTime *chess.PhaseTimes
+}
+
+// computerTurn searches and plays the computer's move
+func (s *session) computerTurn() {
+	position := s.game.Position()
+	result, err := s.engine.SafeSearchGame(s.game)
+	if err != nil {
+		fmt.Println(err)
+		fmt.Println("Playing the first legal move instead")
+		announceMove(position, result.BestMove())
+		s.game.Play(result.BestMove())
+		return
+	}
+	announceMove(position, result.BestMove())
+
+	used, budget := result.MemoryUsage()
+	fmt.Println("Best score found", result.Score(), "at depth", result.Depth())
+	fmt.Println("Search stats", result.Stats())
+	fmt.Println("Search memory used", used / 1024, "KB of", budget / 1024, "KB")
+	if result.Swindled() { fmt.Println("Trying a swindle") }
+	if result.EasyMove() { fmt.Println("Easy move, played right away") }
+
+	s.game.Play(result.BestMove())
+}
+
+// promotionPieceMap has the pieces a pawn can promote to, by their letter
+var promotionPieceMap = map[string]chess.Piece {
+	"q" : chess.Piece_Queen, "r" : chess.Piece_Rock, "b" : chess.Piece_Bishop, "n" : chess.Piece_Knight,
+}
+
+// parsePromotionPiece reads the piece to promote to, as a letter with an optional "=" as in SAN: "q", "=N"
+func parsePromotionPiece(text string) (piece chess.Piece, ok bool) {
+	piece, ok = promotionPieceMap[strings.ToLower(strings.TrimPrefix(text, "="))]
+	return
+}
+
+// hint settings: the quick hint is shown right away, and then the deep hint refines it one depth at a time
+var hintQuickDepth = 2
+var hintQuickTime = 500 * time.Millisecond
+var hintDeepDepth = 8
+var hintDeepTime = 5 * time.Second
+
+// announceMove prints the computer's move in SAN and coordinate notation, both with the promotion piece if any
+func announceMove(position chess.Position, move chess.Board) {
+	fmt.Println("Computer plays", chess.ToSAN(position, move), "(" + chess.DescribeMove(position, move) + ")")
+}
+
+// inputNotation writes a move in the x y diffx diffy format used by playerTurn, after the coordinate notation
+func inputNotation(position chess.Position, move chess.Board) string {
+	description := chess.DescribeMove(position, move)
+	from, _ := chess.SquareFromString(description[:2])
+	to, _ := chess.SquareFromString(description[2:4])
+	return fmt.Sprintf("%s (%d %d %d %d%s)", description, from.File(), 8 - from.Rank(), to.File() - from.File(),
+		from.Rank() - to.Rank(), strings.ToUpper(description[4:]))
+}
+
+// showHints suggests a move for the side to move: a quick one first, and then better ones as a deeper search
+// completes each depth. The deep search starts after the depth the quick one reached, so the same depths aren't
+// shown twice. Both share the table of the session's engine.
+func (s *session) showHints() {
+	position := s.game.Position()
+
+	quick := s.engine.Fork()
+	quick.SetSwindle(false)
+	quick.SetEasyMove(false)
+	quick.SetObserver(nil)
+	quick.SetDepth(hintQuickDepth)
+	quick.SetMoveTime(hintQuickTime)
+	result := quick.Search(position)
+	fmt.Println("Hint:", inputNotation(position, result.BestMove()), "score", result.Score(), "depth", result.Depth())
+
+	deep := quick.Fork()
+	deep.SetDepth(hintDeepDepth)
+	deep.SetMoveTime(hintDeepTime)
+	deep.SetStartDepth(result.Depth() + 1)
+	var legalMoves chess.MoveCache
+	deep.SetObserver(searchObserverFuncs{ iterationComplete: func(info chess.SearchInfo) {
+		move, _ := legalMoves.ParseMove(position, info.PV()[0])
+		fmt.Println("Deeper hint:", inputNotation(position, move), "score", info.Score(), "depth", info.Depth())
+	} })
+	deep.Search(position)
+}
+
+// PlayerAction is what the player chose to do instead of moving
+type PlayerAction uint8
+
+const (
+	PlayerAction_Move PlayerAction = iota
+	PlayerAction_Adjourn
+	PlayerAction_ClaimDraw
+)
+
+// askPromotion asks the player which piece to promote to, unless autoQueen is set
+func (s *session) askPromotion() chess.Piece {
+	if s.autoQueen { return chess.Piece_Queen }
+	fmt.Println("Promote to (q, r, b, n):")
+	text, _ := s.readLine()
+	piece, _ := parsePromotionPiece(text)
+	return piece
+}
+
+// parseMoveText finds the legal move typed by the player in SAN (Nf3, exd5, O-O, e8=Q) or coordinate notation
+// (g1f3, e7e8q). Promotions typed without a piece, like e8 or e7e8, ask for it with askPromotion.
+func (s *session) parseMoveText(text string) (move chess.Board, ok bool) {
+	promotion := chess.Piece_Empty
+	if s.game.MissingPromotion(text) { promotion = s.askPromotion() }
+	return s.game.ParseMove(text, promotion)
+}
+
+// playerTurn asks the player for a move, and plays it. Instead of a move, the player can ask for hints, pause the
+// game (paused is the time spent in pause, which shouldn't count on the clock), adjourn it or claim a draw, in which
+// case action tells which. Closing the input also adjourns the game.
+func (s *session) playerTurn() (paused time.Duration, action PlayerAction) {
+	position := s.game.Position()
+	board := position.Board()
+	color := position.SideToMove()
+
+	for {
+		fmt.Println("Insert your move: Nf3, g1f3 or x y diffx diffy [promotion piece] (or hint, draw, pause, adjourn)")
+		line, ok := s.readLine()
+		if !ok || line == "adjourn" { return paused, PlayerAction_Adjourn }
+		if line == "draw" { return paused, PlayerAction_ClaimDraw }
+		if line == "hint" {
+			s.showHints()
+			continue
+		}
+		if line == "pause" {
+			t := time.Now()
+			fmt.Println("Game paused, the clock is stopped. Press enter to continue")
+			if _, ok := s.readLine(); !ok { return paused, PlayerAction_Adjourn }
+			paused += time.Since(t)
+			s.display.drawTurn(position)
+			continue
+		}
+		if move, ok := s.parseMoveText(line); ok {
+			s.game.Play(move)
+			return paused, PlayerAction_Move
+		}
+
+		var x, y, dx, dy int
+		if _, err := fmt.Sscan(line, &x, &y, &dx, &dy); err != nil {
+			fmt.Println("Can't read move:", err)
+			continue
+		}
+		var promotion string
+		if fields := strings.Fields(line); len(fields) > 4 { promotion = fields[4] }
+
+		inBoard := func(x, y int) bool { return x >= 0 && x < 8 && y >= 0 && y < 8 }
+		if !inBoard(x, y) {
+			fmt.Println("Must select square inside of board")
+			continue
+		}
+		from := chess.SquareFromIndex(x + 8 * y)
+		info := chess.GetBoardAt(board, from)
+		if info.Piece() == chess.Piece_Empty {
+			fmt.Println("Can't select empty piece")
+			continue
+		}
+		if info.Color() != color {
+			fmt.Println("Wrong piece color!")
+			continue
+		}
+		if !inBoard(x + dx, y + dy) {
+			fmt.Println("Can't make move outside of the board!")
+			continue
+		}
+		to := chess.SquareFromIndex(x + dx + 8 * (y + dy))
+		if legal, reason := chess.IsLegal(position, chess.NewFullMove(from, to)); !legal {
+			fmt.Println("Invalid move:", reason)
+			continue
+		}
+
+		text := from.String() + to.String()
+		selectedPiece := chess.Piece_Empty
+		if s.game.MissingPromotion(text) {
+			if promotion == "" && s.autoQueen { promotion = "q" }
+			if promotion == "" {
+				fmt.Println("Promote to (q, r, b, n):")
+				promotion, _ = s.readLine()
+			}
+			if selectedPiece, ok = parsePromotionPiece(promotion); !ok {
+				fmt.Println("Can't promote to", promotion)
+				continue
+			}
+		}
+		if move, ok := s.game.ParseMove(text, selectedPiece); ok {
+			s.game.Play(move)
+			return paused, PlayerAction_Move
+		}
+		fmt.Println("Invalid move!")
+	}
+}
+
+// isHuman tells whether the player of color is a person, the computer plays white unless both are
+func (s *session) isHuman(color chess.PieceColor) bool {
+	return s.players == 2 || (s.players == 1 && color == chess.PieceColor_Black)
+}
+
+// spendTime updates the clock after a move, and tells whether the player who moved lost on time
+func (s *session) spendTime(color chess.PieceColor, used time.Duration) bool {
+	clock := s.game.Clock()
+	if clock == nil { return false }
+	flagged := clock.Spend(color, used)
+	fmt.Println("Clock:", s.display.formatClock(clock))
+	if !flagged && clock.LowTimeWarning(color) { fmt.Println("\aLow time for", color) }
+	return flagged
+}
+
+// play plays the session's game until it ends or the player adjourns it, and returns its result. If the player
+// adjourns the game, the result is unfinished, with Termination_Adjourned.
+func (s *session) play() chess.Result {
+	game := s.game
+	if clock := game.Clock(); clock != nil { fmt.Println("Clock:", s.display.formatClock(clock)) }
+	s.display.drawTurn(game.Position())
+
+	for !game.Result().Finished() {
+		position := game.Position()
+		color := position.SideToMove()
+		if color == chess.PieceColor_White { fmt.Println("Turn:", position.FullmoveNumber()) }
+
+		if !s.isHuman(color) {
+			// the computer takes a draw unless it thinks it's better
+			if _, ok := game.ClaimableDraw(); ok && s.engine.TakesDraw(position) {
+				result, _ := game.ClaimDraw()
+				fmt.Println("Computer claims a draw by", result.Termination())
+				return result
+			}
+			if clock := game.Clock(); clock != nil && clock.IsLow(color) && s.engine.Options().Depth() > chess.LowTimeDepth() {
+				fmt.Println("Low on time, searching to depth", chess.LowTimeDepth())
+			}
+			t := time.Now()
+
+			s.computerTurn()
+
+			spent := time.Since(t)
+			fmt.Println("Time spent by computer", spent)
+			s.computerTime.Add(chess.GetGamePhase(position), spent)
+
+			s.display.drawTurn(game.Position())
+			if s.spendTime(color, spent) && !game.Result().Finished() { return game.TimeForfeit(color) }
+			if s.players == 0 { time.Sleep(s.delay) }
+		} else {
+			t := time.Now()
+			if moves := game.RepetitionMoves(); len(moves) > 0 {
+				descriptions := []string{}
+				for _, move := range moves {
+					descriptions = append(descriptions, move.String())
+				}
+				fmt.Println("Draw by repetition available with", strings.Join(descriptions, ", "))
+			}
+			if termination, ok := game.ClaimableDraw(); ok {
+				fmt.Println("You can claim a draw by", termination, "by typing draw")
+			}
+			paused, action := s.playerTurn()
+			if action == PlayerAction_Adjourn { return game.Adjourn() }
+			if action == PlayerAction_ClaimDraw {
+				if result, ok := game.ClaimDraw(); ok { return result }
+				fmt.Println("There is no draw to claim")
+				if s.spendTime(color, time.Since(t) - paused) { return game.TimeForfeit(color) }
+				continue
+			}
+			s.display.drawTurn(game.Position())
+			if s.spendTime(color, time.Since(t) - paused) && !game.Result().Finished() { return game.TimeForfeit(color) }
+		}
+	}
+	return game.Result()
+}
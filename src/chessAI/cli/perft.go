@@ -0,0 +1,60 @@
+package cli
+
+import "flag"
+import "fmt"
+import "sort"
+import "strconv"
+import "time"
+
+import "chessAI/chess"
+
+// printDivide prints the counts of Divide sorted by move, like other engines do, so they can be compared line by
+// line, and returns the total
+func printDivide(position chess.Position, depth int) (total int) {
+	counts := chess.Divide(position, depth)
+	moves := []string{}
+	for move, count := range counts {
+		moves = append(moves, move)
+		total += count
+	}
+	sort.Strings(moves)
+	for _, move := range moves {
+		fmt.Printf("%s: %d\n", move, counts[move])
+	}
+	fmt.Println("Moves", len(moves), "total", total)
+	return
+}
+
+// Perft runs the perft command, which counts the positions reached at a depth from a position with chess.Perft
+func Perft(args []string) error {
+	flags := flag.NewFlagSet("perft", flag.ContinueOnError)
+	posName := flags.String("pos", "", "name of a built-in position")
+	fen := flags.String("fen", "", "position, in FEN; the initial position if neither -fen nor -pos is given")
+	split := flags.Bool("divide", false, "show the count for each move")
+	unmake := flags.Bool("unmake", false, "make and unmake moves on one position instead of copying it, to compare their speed")
+	flags.Usage = func() {
+		fmt.Println("Usage: perft [options] <depth>")
+		flags.PrintDefaults()
+	}
+	if err := parseFlags(flags, args); err != nil { return err }
+
+	depth, err := strconv.Atoi(flags.Arg(0))
+	if flags.NArg() != 1 || err != nil || depth < 1 { return badUsage(flags) }
+	position, err := loadPosition(*posName, *fen, false)
+	if err != nil { return err }
+
+	start := time.Now()
+	var count int
+	switch {
+	case *split:
+		count = printDivide(position, depth)
+	case *unmake:
+		count = chess.PerftUnmake(&position, depth)
+	default:
+		count = chess.Perft(position, depth)
+	}
+	elapsed := time.Since(start)
+	fmt.Printf("Perft %d: %d in %v (%.0f positions/s)\n", depth, count, elapsed.Round(time.Millisecond),
+		float64(count) / elapsed.Seconds())
+	return nil
+}
@@ -0,0 +1,30 @@
+package cli
+
+import "flag"
+import "fmt"
+import "math/rand"
+
+import "chessAI/chess"
+
+// Stats runs the stats command, which prints game tree statistics for a set of positions
+func Stats(args []string) error {
+	flags := flag.NewFlagSet("stats", flag.ContinueOnError)
+	random := flags.Int("random", 0, "use this many random positions instead of the built-in ones")
+	seed := flags.Int64("seed", 1, "seed for generating the random positions")
+	if err := parseFlags(flags, args); err != nil { return err }
+
+	var total chess.MoveStats
+	if *random > 0 {
+		for _, p := range chess.RandomPositions(rand.New(rand.NewSource(*seed)), *random) {
+			total.Add(chess.GetMoveStats(p))
+		}
+	} else {
+		for _, name := range chess.NamedPositionNames() {
+			stats := chess.GetMoveStats(namedPosition(name))
+			fmt.Printf("%-15s %v\n", name, stats)
+			total.Add(stats)
+		}
+	}
+	fmt.Println("total:", total)
+	return nil
+}
@@ -0,0 +1,221 @@
+package cli
+
+import "fmt"
+import "math"
+import "os"
+import "runtime"
+import "strconv"
+import "strings"
+
+import "chessAI/chess"
+
+type RenderMode uint8
+
+const (
+	RenderMode_Unicode RenderMode = iota // chess symbols for pieces and squares
+	RenderMode_ASCII // FEN letters for pieces, white uppercase and black lowercase
+)
+
+var renderModeNamesMap = map[RenderMode]string { RenderMode_Unicode : "unicode", RenderMode_ASCII : "ascii" }
+
+func (m RenderMode) String() string {
+	return renderModeNamesMap[m]
+}
+
+// Terminal describes what the output can show
+type Terminal struct {
+	mode RenderMode
+	color bool // whether ANSI colors can be used
+	width int // in columns
+}
+
+type SquareColor bool
+
+const (
+	SquareColor_White SquareColor = true
+	SquareColor_Black = false
+)
+
+// we assume background is white, otherwise the colors will look reverted
+var pieceCharMap = map[chess.PieceColor]map[chess.Piece]string {
+	chess.PieceColor_White : {
+		chess.Piece_Empty : ` `, chess.Piece_Pawn : `♙`, chess.Piece_Rock : `♖`, chess.Piece_Knight : `♘`,
+		chess.Piece_Bishop : `♗`, chess.Piece_King : `♔`, chess.Piece_Queen : `♕`, },
+	chess.PieceColor_Black : {
+		chess.Piece_Empty : ` `, chess.Piece_Pawn : `♟`, chess.Piece_Rock : `♜`, chess.Piece_Knight : `♞`,
+		chess.Piece_Bishop : `♝`, chess.Piece_King : `♚`, chess.Piece_Queen : `♛`, },
+}
+
+var squareCharMap  = map[SquareColor]string { SquareColor_White : ` `, SquareColor_Black : `▨`, }
+var asciiSquareCharMap = map[SquareColor]string { SquareColor_White : ` `, SquareColor_Black : `.`, }
+
+const ansiDarkSquare = "\x1b[47m"
+const ansiRed = "\x1b[31m"
+const ansiReset = "\x1b[0m"
+
+// DetectTerminal guesses the terminal capabilities from the environment. CHESSAI_RENDER (unicode or ascii) overrides
+// the render mode, and NO_COLOR disables colors as usual.
+func DetectTerminal() Terminal {
+	t := Terminal{ RenderMode_ASCII, false, 80 }
+
+	locale := os.Getenv("LC_ALL")
+	if locale == "" { locale = os.Getenv("LC_CTYPE") }
+	if locale == "" { locale = os.Getenv("LANG") }
+	locale = strings.ToUpper(locale)
+	if strings.Contains(locale, "UTF-8") || strings.Contains(locale, "UTF8") { t.mode = RenderMode_Unicode }
+
+	// the Windows console only renders these properly in Windows Terminal
+	if runtime.GOOS == "windows" && os.Getenv("WT_SESSION") != "" { t.mode = RenderMode_Unicode }
+
+	term := os.Getenv("TERM")
+	t.color = term != "" && term != "dumb" && os.Getenv("CI") == ""
+	if _, ok := os.LookupEnv("NO_COLOR"); ok { t.color = false }
+
+	if mode, ok := ParseRenderMode(os.Getenv("CHESSAI_RENDER")); ok { t.mode = mode }
+
+	if columns, err := strconv.Atoi(os.Getenv("COLUMNS")); err == nil && columns > 0 { t.width = columns }
+
+	return t
+}
+
+// ParseRenderMode reads a render mode name
+func ParseRenderMode(name string) (mode RenderMode, ok bool) {
+	for mode, modeName := range renderModeNamesMap {
+		if modeName == strings.ToLower(name) { return mode, true }
+	}
+	return
+}
+
+// Orientation decides which side of the board is drawn at the bottom
+type Orientation uint8
+
+const (
+	Orientation_White Orientation = iota
+	Orientation_Black
+	Orientation_SideToMove // flips the board after every move
+)
+
+var orientationNamesMap = map[Orientation]string {
+	Orientation_White : "white", Orientation_Black : "black", Orientation_SideToMove : "flip",
+}
+
+func (o Orientation) String() string {
+	return orientationNamesMap[o]
+}
+
+// ParseOrientation reads an orientation by its name
+func ParseOrientation(name string) (orientation Orientation, ok bool) {
+	for orientation, orientationName := range orientationNamesMap {
+		if name == orientationName { return orientation, true }
+	}
+	return
+}
+
+// bottomColor tells whose pieces are drawn at the bottom when color is to move
+func (o Orientation) bottomColor(color chess.PieceColor) chess.PieceColor {
+	switch o {
+	case Orientation_Black:
+		return chess.PieceColor_Black
+	case Orientation_SideToMove:
+		return color
+	}
+	return chess.PieceColor_White
+}
+
+// display draws boards for a terminal, with its pieces at the bottom as orientation says
+type display struct {
+	terminal Terminal
+	orientation Orientation
+}
+
+// newDisplay returns a display for the terminal DetectTerminal finds, with white at the bottom
+func newDisplay() display {
+	return display{ DetectTerminal(), Orientation_White }
+}
+
+func (d display) drawPiece(info chess.PieceInfo, square SquareColor) {
+	printSquares := true
+	debugStatus := false
+
+	if debugStatus {
+		if info.Piece() == chess.Piece_Pawn && info.Status() == chess.PieceStatus_EnPassantAllowed {
+				fmt.Print(" P")
+				return
+		}
+		if info.Piece() == chess.Piece_Rock && info.Status() == chess.PieceStatus_CastlingNotAllowed {
+				fmt.Print(" R")
+				return
+		}
+		if info.Piece() == chess.Piece_King && info.Status() == chess.PieceStatus_CastlingNotAllowed {
+				fmt.Print(" K")
+				return
+		}
+	}
+
+	char := " "
+	if info.Piece() != chess.Piece_Empty {
+		char = pieceCharMap[info.Color()][info.Piece()]
+		if d.terminal.mode == RenderMode_ASCII { char = info.String() }
+	} else if printSquares && !d.terminal.color {
+		char = squareCharMap[square]
+		if d.terminal.mode == RenderMode_ASCII { char = asciiSquareCharMap[square] }
+	}
+
+	// with colors, dark squares get a background instead of a pattern
+	char = " " + char
+	if d.terminal.color && square == SquareColor_Black { char = ansiDarkSquare + char + ansiReset }
+	fmt.Print(char)
+}
+
+// drawBoard draws the board as seen by the player of color bottom, with its pieces at the bottom
+func (d display) drawBoard(board chess.Board, bottom chess.PieceColor) {
+	// coordinates in the order they're drawn
+	order := []int{ 0, 1, 2, 3, 4, 5, 6, 7 }
+	if bottom == chess.PieceColor_Black { order = []int{ 7, 6, 5, 4, 3, 2, 1, 0 } }
+
+	indexes, files := " ", " "
+	for _, x := range order {
+		indexes += fmt.Sprint(" ", x)
+		files += fmt.Sprint(" ", string(rune('a' + x)))
+	}
+	fmt.Println(indexes)
+
+	for _, y := range order {
+		fmt.Print(y)
+		
+		for _, x := range order {
+			info := chess.GetBoardAt(board, chess.SquareFromIndex(x + 8 * y))
+			squareColor := SquareColor_White
+			if (x + y) % 2 == 1 { squareColor = SquareColor_Black }
+			d.drawPiece(info, squareColor)
+		}
+		fmt.Println("", 8 - y)
+	}
+	fmt.Println(files)
+}
+
+// statusLine describes everything about the position that the board doesn't show
+func statusLine(position chess.Position) string {
+	enPassant := "-"
+	if square, ok := position.EnPassantSquare(); ok { enPassant = square.String() }
+
+	return fmt.Sprint(position.SideToMove(), " to move, move ", position.FullmoveNumber(),
+		", castling ", position.CastlingRights(), ", en passant ", enPassant, ", halfmove clock ", position.HalfmoveClock())
+}
+
+func (d display) drawTurn(position chess.Position) {
+	fmt.Println("Color", position.SideToMove(), "turn:")
+	d.drawBoard(position.Board(), d.orientation.bottomColor(position.SideToMove()))
+	fmt.Println(statusLine(position))
+	fmt.Println(strings.Repeat("=", int(math.Min(27, float64(d.terminal.width)))))
+}
+
+// formatClock writes the time left on both sides of clock, in red when it's low if the terminal has colors
+func (d display) formatClock(clock *chess.Clock) string {
+	format := func(color chess.PieceColor) string {
+		remaining := clock.FormatRemaining(color)
+		if d.terminal.color && clock.IsLow(color) { remaining = ansiRed + remaining + ansiReset }
+		return remaining
+	}
+	return fmt.Sprint("White ", format(chess.PieceColor_White), ", Black ", format(chess.PieceColor_Black))
+}
@@ -0,0 +1,36 @@
+package cli
+
+import "errors"
+import "flag"
+import "fmt"
+import "math/rand"
+import "strings"
+
+import "chessAI/chess"
+
+// Verify runs the verify command, which runs internal consistency checks over random positions
+func Verify(args []string) error {
+	flags := flag.NewFlagSet("verify", flag.ContinueOnError)
+	count := flags.Int("positions", 50, "number of random positions to check")
+	seed := flags.Int64("seed", 1, "seed for generating the random positions")
+	flags.Usage = func() {
+		fmt.Println("Usage: verify [options] " + strings.Join(chess.VerificationNames(), "|"))
+		flags.PrintDefaults()
+	}
+	if err := parseFlags(flags, args); err != nil { return err }
+
+	if flags.NArg() != 1 { return badUsage(flags) }
+	known := false
+	for _, name := range chess.VerificationNames() {
+		known = known || name == flags.Arg(0)
+	}
+	if !known { return badUsage(flags) }
+
+	positions := chess.RandomPositions(rand.New(rand.NewSource(*seed)), *count)
+	if err := chess.Verify(flags.Arg(0), positions); err != nil {
+		fmt.Println(err)
+		return errors.New("FAILED")
+	}
+	fmt.Println("OK,", len(positions), "positions checked")
+	return nil
+}
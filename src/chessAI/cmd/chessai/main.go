@@ -0,0 +1,95 @@
+// Command chessAI plays chess in the terminal, and runs the tools that test and tune the engine
+package main
+
+import "errors"
+import "flag"
+import "fmt"
+import "os"
+
+import "chessAI/chess"
+import "chessAI/cli"
+
+// subcommands run with the arguments after their name
+var subcommands = map[string]func(args []string) error {
+	"analyze": cli.Analyze,
+	"stats": cli.Stats,
+	"verify": cli.Verify,
+	"debug": cli.Debug,
+	"bench": cli.Bench,
+	"bench-compare": cli.BenchCompare,
+	"perft": cli.Perft,
+	"curriculum": cli.Curriculum,
+}
+
+func main() {
+	fmt.Println("Chess AI")
+
+	if len(os.Args) > 1 {
+		switch os.Args[1] {
+		case "version", "-version", "--version":
+			fmt.Println(chess.EngineName(), "by", chess.Author())
+			return
+		}
+		if run, ok := subcommands[os.Args[1]]; ok {
+			exit(run(os.Args[2:]))
+			return
+		}
+	}
+	exit(playGame())
+}
+
+// playGame plays the game the flags describe
+func playGame() error {
+	config := cli.DefaultGameConfig()
+	flag.BoolVar(&config.LowMemory, "low-memory", false, "use the low memory profile, overriding -memory")
+	flag.IntVar(&config.MemoryMB, "memory", config.MemoryMB, "maximum memory used by the search, in MB")
+	swindle := flag.Bool("swindle", false, "when losing, prefer tricky moves over objectively best ones")
+	depth := flag.Int("depth", chess.DefaultSearchOptions().Depth(), "maximum search depth, in plies")
+	flag.DurationVar(&config.MoveTime, "movetime", 0, "time the computer can spend on each move, 0 means no limit (overridden by -time)")
+	easyMove := flag.Bool("easymove", chess.DefaultSearchOptions().EasyMove(), "with a time limit, play obvious moves without using the time available")
+	flag.DurationVar(&config.MoveOverhead, "overhead", config.MoveOverhead, "time kept aside on each move for everything but the search")
+	flag.DurationVar(&config.Time, "time", 0, "time on each player's clock, 0 means no clock")
+	flag.DurationVar(&config.Increment, "inc", 0, "time added to a player's clock after each move")
+	flag.DurationVar(&config.WhiteTime, "white-time", 0, "time on white's clock, overriding -time to give time odds")
+	flag.DurationVar(&config.BlackTime, "black-time", 0, "time on black's clock, overriding -time to give time odds")
+	flag.StringVar(&config.ResultFile, "result", "", "write the result of the game to this file, as JSON")
+	flag.StringVar(&config.PGNFile, "pgn", "", "write the game to this file, in PGN")
+	flag.StringVar(&config.Event, "event", config.Event, "event name in the PGN tags of -pgn")
+	flag.StringVar(&config.AdjournFile, "adjourn-file", config.AdjournFile, "where adjourned games are saved")
+	flag.BoolVar(&config.Resume, "resume", false, "resume the game saved in -adjourn-file")
+	flag.BoolVar(&config.AutoQueen, "auto-queen", false, "promote pawns to queens without asking")
+	flag.BoolVar(&config.Bell, "bell", false, "ring the terminal bell on captures and checks")
+	flag.StringVar(&config.Rules, "rules", config.Rules, "how draws are applied: casual (as soon as possible) or fide (threefold repetition and fifty moves have to be claimed by typing draw)")
+	flag.IntVar(&config.Players, "players", config.Players, "0 to watch the computer play itself, 1 to play against it, 2 for two players")
+	flag.StringVar(&config.Orientation, "orientation", config.Orientation, "side drawn at the bottom of the board: white, black, or flip to follow the side to move")
+	flag.DurationVar(&config.Delay, "delay", 0, "with -players 0, pause this long after each move")
+	flag.StringVar(&config.Render, "render", config.Render, "how to draw the board: auto, unicode or ascii")
+	flag.StringVar(&config.Color, "color", config.Color, "use colors in the board: auto, always or never")
+	flag.StringVar(&config.Bot, "bot", config.Bot, "computer opponent to play, one of:\n" + chess.DescribeBots())
+	flag.Parse()
+
+	// the search flags given override the bot's settings
+	flag.Visit(func(f *flag.Flag) {
+		switch f.Name {
+		case "depth":
+			config.Depth = depth
+		case "swindle":
+			config.Swindle = swindle
+		case "easymove":
+			config.EasyMove = easyMove
+		}
+	})
+	return cli.RunGame(config)
+}
+
+// exit ends the program with the status for err: 2 for wrong arguments, as the flag package does, 1 for failures
+func exit(err error) {
+	if err == nil || errors.Is(err, flag.ErrHelp) { return }
+	var usage cli.UsageError
+	if errors.As(err, &usage) {
+		if !usage.Reported() { fmt.Println(err) }
+		os.Exit(2)
+	}
+	fmt.Println(err)
+	os.Exit(1)
+}
@@ -1,10 +0,0 @@
-package main
-
-import "fmt"
-
-func main () {
-	fmt.Println("Chess AI")
-
-	PlayGame(1)
-}
-